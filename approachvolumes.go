@@ -0,0 +1,287 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ApproachVolumesExport holds the ATPA approach volumes and CRDA runway
+// pairs of an ARTCC's TRACONs as vice STARS adaptation. With
+// -approach-debug-maps, DebugMaps holds STARS video maps that draw them.
+type ApproachVolumesExport struct {
+	Facilities []STARSApproachVolumes `json:"facilities"`
+	DebugMaps  []STARSMap             `json:"debug_maps,omitempty"`
+}
+
+type STARSApproachVolumes struct {
+	Facility  string           `json:"facility"`
+	ATPA      []ATPAVolume     `json:"atpa_volumes"`
+	CRDAPairs []CRDARunwayPair `json:"crda_runway_pairs"`
+}
+
+type ATPAVolume struct {
+	ID                  string   `json:"id"`
+	Airport             string   `json:"airport"`
+	Name                string   `json:"name"`
+	Threshold           Point2LL `json:"runway_threshold"` // [lon, lat]
+	Heading             float32  `json:"heading"`          // magnetic
+	MaxHeadingDeviation float32  `json:"max_heading_deviation"`
+	Floor               float32  `json:"floor"`      // feet
+	Ceiling             float32  `json:"ceiling"`    // feet
+	Length              float32  `json:"length"`     // nm
+	LeftWidth           float32  `json:"left_width"` // feet
+	RightWidth          float32  `json:"right_width"`
+	FilteredScratchpads []string `json:"filtered_scratchpads,omitempty"`
+	ExcludedScratchpads []string `json:"excluded_scratchpads,omitempty"`
+	Enable25nmApproach  bool     `json:"enable_2.5nm"`
+	Dist25nmApproach    float32  `json:"dist_2.5nm"` // nm
+	TCPs                []string `json:"tcps,omitempty"`
+}
+
+// CRDARunwayPair is a STARS runway pair configuration: approaches to the
+// master runway are ghosted onto the slave runway and vice versa.
+type CRDARunwayPair struct {
+	Index         int        `json:"index"`
+	Airport       string     `json:"airport"`
+	TieSymbol     string     `json:"tie_symbol"`
+	StaggerSymbol string     `json:"stagger_symbol"`
+	Master        CRDARunway `json:"master"`
+	Slave         CRDARunway `json:"slave"`
+}
+
+type CRDARunway struct {
+	Runway                     string   `json:"runway"`
+	HeadingTolerance           float32  `json:"heading_tolerance"`
+	NearSideHalfWidth          float32  `json:"near_side_half_width"` // nm
+	FarSideHalfWidth           float32  `json:"far_side_half_width"`  // nm
+	NearSideDistance           float32  `json:"near_side_distance"`   // nm
+	RegionLength               float32  `json:"region_length"`        // nm
+	TargetReferencePoint       Point2LL `json:"target_reference_point"`
+	TargetReferenceLineHeading float32  `json:"target_reference_line_heading"`
+	TargetReferenceLineLength  float32  `json:"target_reference_line_length"`
+	TargetReferenceAltitude    float32  `json:"target_reference_altitude"`
+	ImageReferencePoint        Point2LL `json:"image_reference_point"`
+	ImageReferenceLineHeading  float32  `json:"image_reference_line_heading"`
+	ImageReferenceLineLength   float32  `json:"image_reference_line_length"`
+	TieModeOffset              float32  `json:"tie_mode_offset"`
+	DescentPointDistance       float32  `json:"descent_point_distance"`
+	DescentPointAltitude       float32  `json:"descent_point_altitude"`
+	AbovePathTolerance         float32  `json:"above_path_tolerance"`
+	BelowPathTolerance         float32  `json:"below_path_tolerance"`
+	DefaultLeaderDirection     string   `json:"default_leader_direction"`
+	ScratchpadPatterns         []string `json:"scratchpad_patterns,omitempty"`
+}
+
+// feetPerNM converts ATPA volume widths, which are in feet, to nm.
+const feetPerNM = 6076.12
+
+// debugMapBase is the STARS map number of the first debug map; they're
+// numbered well above those of real facilities' maps.
+const debugMapBase = 900
+
+func exportApproachVolumes(ctx *exportContext) (any, error) {
+	out := ApproachVolumesExport{Facilities: []STARSApproachVolumes{}}
+
+	for _, child := range ctx.artcc.Facility.ChildFacilities {
+		sc := &child.StarsConfiguration
+		if len(sc.AtpaVolumes) == 0 && len(sc.Rpcs) == 0 {
+			continue
+		}
+
+		magVar, ok := ctx.opts.magVar.lookup(child.ID)
+		if ctx.opts.approachDebugMaps && len(sc.AtpaVolumes) > 0 && !ok {
+			return nil, fmt.Errorf("%s: no magnetic variation to draw ATPA volumes with; give it with -mag-var %s=<degrees>",
+				child.ID, child.ID)
+		}
+
+		fac := STARSApproachVolumes{Facility: child.ID, ATPA: []ATPAVolume{}, CRDAPairs: []CRDARunwayPair{}}
+		atpaMap := STARSMap{Label: child.ID + " ATPA", Name: child.ID + " ATPA volumes"}
+		crdaMap := STARSMap{Label: child.ID + " CRDA", Name: child.ID + " CRDA regions"}
+
+		for _, v := range sc.AtpaVolumes {
+			vol := ATPAVolume{
+				ID:                  v.VolumeID,
+				Airport:             v.AirportID,
+				Name:                v.Name,
+				Threshold:           Point2LL{float32(v.RunwayThreshold.Lon), float32(v.RunwayThreshold.Lat)},
+				Heading:             v.MagneticHeading,
+				MaxHeadingDeviation: v.MaximumHeadingDeviation,
+				Floor:               v.Floor,
+				Ceiling:             v.Ceiling,
+				Length:              v.Length,
+				LeftWidth:           v.WidthLeft,
+				RightWidth:          v.WidthRight,
+				Enable25nmApproach:  v.TwoPointFiveApproachEnabled,
+				Dist25nmApproach:    v.TwoPointFiveApproachDistance,
+			}
+			for _, sp := range v.Scratchpads {
+				if strings.EqualFold(sp.Type, "exclude") {
+					vol.ExcludedScratchpads = append(vol.ExcludedScratchpads, sp.Entry)
+				} else {
+					vol.FilteredScratchpads = append(vol.FilteredScratchpads, sp.Entry)
+				}
+			}
+			for _, t := range v.Tcps {
+				vol.TCPs = append(vol.TCPs, t.TCP)
+			}
+			fac.ATPA = append(fac.ATPA, vol)
+
+			// The volume extends back along the final approach course
+			// from the threshold; left and right are as seen by an
+			// arriving aircraft.
+			hdg := float64(v.MagneticHeading) + magVar
+			far := offsetPoint(vol.Threshold, hdg+180, float64(v.Length))
+			left, right := float64(v.WidthLeft)/feetPerNM, float64(v.WidthRight)/feetPerNM
+			atpaMap.Lines = append(atpaMap.Lines, []Point2LL{
+				offsetPoint(vol.Threshold, hdg-90, left),
+				offsetPoint(far, hdg-90, left),
+				offsetPoint(far, hdg+90, right),
+				offsetPoint(vol.Threshold, hdg+90, right),
+				offsetPoint(vol.Threshold, hdg-90, left),
+			})
+		}
+
+		for _, rpc := range sc.Rpcs {
+			pair := CRDARunwayPair{
+				Index:         int(rpc.Index),
+				Airport:       rpc.AirportID,
+				TieSymbol:     rpc.PositionSymbolTie,
+				StaggerSymbol: rpc.PositionSymbolStagger,
+				Master:        convertRPCRunway(&rpc.MasterRunway),
+				Slave:         convertRPCRunway(&rpc.SlaveRunway),
+			}
+			fac.CRDAPairs = append(fac.CRDAPairs, pair)
+			crdaMap.Lines = append(crdaMap.Lines, crdaRunwayLines(&pair.Master)...)
+			crdaMap.Lines = append(crdaMap.Lines, crdaRunwayLines(&pair.Slave)...)
+		}
+
+		ctx.lg.Printf("  %s: %d ATPA volume(s), %d CRDA runway pair(s)", child.ID, len(fac.ATPA), len(fac.CRDAPairs))
+		out.Facilities = append(out.Facilities, fac)
+
+		if ctx.opts.approachDebugMaps {
+			for _, m := range []STARSMap{atpaMap, crdaMap} {
+				if len(m.Lines) == 0 {
+					continue
+				}
+				m.Id = debugMapBase + len(out.DebugMaps)
+				m.VideoMapId = strings.ReplaceAll(strings.ToLower(m.Label), " ", "-")
+				out.DebugMaps = append(out.DebugMaps, m)
+			}
+		}
+	}
+	return out, nil
+}
+
+// magVarFlag holds the magnetic variation of TRACONs, in degrees with
+// east positive, given with -mag-var. It implements flag.Value and
+// accepts a comma-separated list of TRACON=variation entries; a bare
+// variation applies to every TRACON without an entry of its own. The
+// default is stored under the empty key.
+type magVarFlag map[string]float64
+
+func (m magVarFlag) String() string {
+	var s []string
+	for _, k := range slices.Sorted(maps.Keys(m)) {
+		v := strconv.FormatFloat(m[k], 'g', -1, 64)
+		if k != "" {
+			v = k + "=" + v
+		}
+		s = append(s, v)
+	}
+	return strings.Join(s, ",")
+}
+
+func (m magVarFlag) Set(s string) error {
+	for _, entry := range strings.Split(s, ",") {
+		facility, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			facility, value = "", facility
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return fmt.Errorf("%s: invalid magnetic variation: %v", entry, err)
+		}
+		if v < -180 || v > 180 {
+			return fmt.Errorf("%s: magnetic variation must be between -180 and 180 degrees", entry)
+		}
+		m[strings.ToUpper(strings.TrimSpace(facility))] = v
+	}
+	return nil
+}
+
+// lookup returns the magnetic variation of the given TRACON.
+func (m magVarFlag) lookup(facility string) (float64, bool) {
+	if v, ok := m[facility]; ok {
+		return v, true
+	}
+	v, ok := m[""]
+	return v, ok
+}
+
+func convertRPCRunway(r *RPCRunway) CRDARunway {
+	return CRDARunway{
+		Runway:                     r.RunwayID,
+		HeadingTolerance:           r.HeadingTolerance,
+		NearSideHalfWidth:          r.NearSideHalfWidth,
+		FarSideHalfWidth:           r.FarSideHalfWidth,
+		NearSideDistance:           r.NearSideDistance,
+		RegionLength:               r.RegionLength,
+		TargetReferencePoint:       Point2LL{float32(r.TargetReferencePoint.Lon), float32(r.TargetReferencePoint.Lat)},
+		TargetReferenceLineHeading: r.TargetReferenceLineHeading,
+		TargetReferenceLineLength:  r.TargetReferenceLineLength,
+		TargetReferenceAltitude:    r.TargetReferencePointAltitude,
+		ImageReferencePoint:        Point2LL{float32(r.ImageReferencePoint.Lon), float32(r.ImageReferencePoint.Lat)},
+		ImageReferenceLineHeading:  r.ImageReferenceLineHeading,
+		ImageReferenceLineLength:   r.ImageReferenceLineLength,
+		TieModeOffset:              r.TieModeOffset,
+		DescentPointDistance:       r.DescentPointDistance,
+		DescentPointAltitude:       r.DescentPointAltitude,
+		AbovePathTolerance:         r.AbovePathTolerance,
+		BelowPathTolerance:         r.BelowPathTolerance,
+		DefaultLeaderDirection:     r.DefaultLeaderDirection,
+		ScratchpadPatterns:         r.ScratchpadPatterns,
+	}
+}
+
+// crdaRunwayLines returns the outline of a CRDA runway's qualification
+// region along with its target and image reference lines. The region
+// and lines extend from the reference points back along the approach
+// course given by the line headings, which are true.
+func crdaRunwayLines(r *CRDARunway) [][]Point2LL {
+	hdg := float64(r.TargetReferenceLineHeading)
+	near := offsetPoint(r.TargetReferencePoint, hdg+180, float64(r.NearSideDistance))
+	far := offsetPoint(near, hdg+180, float64(r.RegionLength))
+	nearW, farW := float64(r.NearSideHalfWidth), float64(r.FarSideHalfWidth)
+
+	region := []Point2LL{
+		offsetPoint(near, hdg-90, nearW),
+		offsetPoint(far, hdg-90, farW),
+		offsetPoint(far, hdg+90, farW),
+		offsetPoint(near, hdg+90, nearW),
+		offsetPoint(near, hdg-90, nearW),
+	}
+	target := []Point2LL{
+		r.TargetReferencePoint,
+		offsetPoint(r.TargetReferencePoint, hdg+180, float64(r.TargetReferenceLineLength)),
+	}
+	image := []Point2LL{
+		r.ImageReferencePoint,
+		offsetPoint(r.ImageReferencePoint, float64(r.ImageReferenceLineHeading)+180, float64(r.ImageReferenceLineLength)),
+	}
+	return [][]Point2LL{region, target, image}
+}
+
+// offsetPoint returns the point dist nm from p in the direction of the
+// true heading hdg, using the same local equirectangular projection as
+// nmDistance.
+func offsetPoint(p Point2LL, hdg, dist float64) Point2LL {
+	rad := hdg * math.Pi / 180
+	lat := float64(p[1]) + dist*math.Cos(rad)/nmPerDegreeLatitude
+	// Scale longitude at the mean latitude, as nmDistance does.
+	nmPerDegreeLongitude := nmPerDegreeLatitude * math.Cos((float64(p[1])+lat)/2*math.Pi/180)
+	return Point2LL{p[0] + float32(dist*math.Sin(rad)/nmPerDegreeLongitude), float32(lat)}
+}
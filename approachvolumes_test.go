@@ -0,0 +1,50 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMagVarFlag(t *testing.T) {
+	m := make(magVarFlag)
+	if err := m.Set("-13, n90=-12.5"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, tc := range []struct {
+		facility string
+		expected float64
+	}{
+		{"N90", -12.5},
+		{"PHL", -13},
+	} {
+		if v, ok := m.lookup(tc.facility); !ok || v != tc.expected {
+			t.Errorf("%s: got %v, %t, expected %v", tc.facility, v, ok, tc.expected)
+		}
+	}
+
+	m = make(magVarFlag)
+	if err := m.Set("N90=-12"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := m.lookup("PHL"); ok {
+		t.Error("PHL: expected no variation without a default")
+	}
+
+	for _, s := range []string{"", "N90=", "N90=west", "200"} {
+		if err := make(magVarFlag).Set(s); err == nil {
+			t.Errorf("%q: expected an error", s)
+		}
+	}
+}
+
+func TestOffsetPoint(t *testing.T) {
+	for _, lat := range []float32{25, 65} {
+		p := Point2LL{-100, lat}
+		for _, hdg := range []float64{0, 45, 90, 222} {
+			q := offsetPoint(p, hdg, 10)
+			if d := nmDistance(p, q); math.Abs(d-10) > 0.01 {
+				t.Errorf("lat %v heading %v: offset %.4f nm, expected 10 nm", lat, hdg, d)
+			}
+		}
+	}
+}
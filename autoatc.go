@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AutoATCExport holds an ARTCC's CRC auto-ATC rules converted to vice
+// arrival crossing restrictions, along with the rules that couldn't be
+// converted.
+type AutoATCExport struct {
+	Restrictions []ArrivalRestriction `json:"restrictions"`
+	Unmapped     []UnmappedRule       `json:"unmapped"`
+}
+
+// ArrivalRestriction is a descent clearance given to inbound aircraft
+// that match its route, departure, destination and aircraft class
+// criteria.
+type ArrivalRestriction struct {
+	ID                     string   `json:"id"`
+	Name                   string   `json:"name"`
+	Position               string   `json:"position,omitempty"` // controlling position ID
+	RouteSubstrings        []string `json:"route_substrings,omitempty"`
+	ExcludeRouteSubstrings []string `json:"exclude_route_substrings,omitempty"`
+	Departures             []string `json:"departures,omitempty"`
+	Destinations           []string `json:"destinations,omitempty"`
+	AircraftClasses        []string `json:"aircraft_classes"` // "jet", "turboprop", "prop"
+	Fix                    string   `json:"fix"`
+	Altitude               int      `json:"altitude"` // feet
+	Constraint             string   `json:"constraint"`
+	// Waypoint is the fix with the restriction in vice route syntax,
+	// e.g. "CAMRN/a11000-".
+	Waypoint         string `json:"waypoint"`
+	AltimeterStation string `json:"altimeter_station,omitempty"`
+}
+
+type UnmappedRule struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// altitudeConstraints maps CRC constraint types to their vice names and
+// waypoint restriction suffixes.
+var altitudeConstraints = map[string]struct{ name, suffix string }{
+	"at":        {"at", ""},
+	"atorabove": {"at_or_above", "+"},
+	"atorbelow": {"at_or_below", "-"},
+}
+
+func exportAutoATCRules(ctx *exportContext) (any, error) {
+	out := AutoATCExport{Restrictions: []ArrivalRestriction{}, Unmapped: []UnmappedRule{}}
+
+	for _, rule := range ctx.artcc.AutoAtcRules {
+		unmapped := func(format string, args ...any) {
+			reason := fmt.Sprintf(format, args...)
+			ctx.lg.Printf("  Rule %q not converted: %s", rule.Name, reason)
+			out.Unmapped = append(out.Unmapped, UnmappedRule{ID: rule.ID, Name: rule.Name, Reason: reason})
+		}
+
+		if rule.Status != "" && !strings.EqualFold(rule.Status, "active") {
+			unmapped("status is %q", rule.Status)
+			continue
+		}
+		if len(rule.PrecursorRules) > 0 || len(rule.ExclusionaryRules) > 0 {
+			unmapped("depends on precursor or exclusionary rules")
+			continue
+		}
+
+		xr := rule.DescentCrossingRestriction
+		if xr == nil {
+			if rule.DescentRestriction != nil {
+				unmapped("crossing line restrictions aren't supported; only crossing fixes are")
+			} else {
+				unmapped("no descent crossing restriction")
+			}
+			continue
+		}
+		if xr.CrossingFix == "" {
+			unmapped("no crossing fix")
+			continue
+		}
+
+		alt := xr.AltitudeConstraint
+		c, ok := altitudeConstraints[strings.ToLower(alt.ConstraintType)]
+		if !ok {
+			unmapped("unknown altitude constraint type %q", alt.ConstraintType)
+			continue
+		}
+		if alt.IsLufl {
+			unmapped("lowest usable flight level depends on the altimeter setting")
+			continue
+		}
+		if alt.Value <= 0 {
+			unmapped("no crossing altitude")
+			continue
+		}
+
+		crit := &rule.Criteria
+		var classes []string
+		if crit.ApplicableToJets {
+			classes = append(classes, "jet")
+		}
+		if crit.ApplicableToTurboprops {
+			classes = append(classes, "turboprop")
+		}
+		if crit.ApplicableToProps {
+			classes = append(classes, "prop")
+		}
+		if len(classes) == 0 {
+			unmapped("doesn't apply to any aircraft class")
+			continue
+		}
+		if len(crit.RouteSubstrings) == 0 && len(crit.Departures) == 0 && len(crit.Destinations) == 0 {
+			unmapped("no route, departure or destination criteria")
+			continue
+		}
+
+		altitude := int(alt.Value)
+		out.Restrictions = append(out.Restrictions, ArrivalRestriction{
+			ID:                     rule.ID,
+			Name:                   rule.Name,
+			Position:               rule.PositionID,
+			RouteSubstrings:        crit.RouteSubstrings,
+			ExcludeRouteSubstrings: crit.ExcludeRouteSubstrings,
+			Departures:             crit.Departures,
+			Destinations:           crit.Destinations,
+			AircraftClasses:        classes,
+			Fix:                    xr.CrossingFix,
+			Altitude:               altitude,
+			Constraint:             c.name,
+			Waypoint:               fmt.Sprintf("%s/a%d%s", xr.CrossingFix, altitude, c.suffix),
+			AltimeterStation:       xr.AltimeterStation.StationID,
+		})
+	}
+
+	ctx.lg.Printf("  %d auto-ATC rules converted, %d not converted", len(out.Restrictions), len(out.Unmapped))
+	return out, nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// artccSummary records the outcome of processing one ARTCC for the
+// end-of-run summary table.
+type artccSummary struct {
+	ID      string
+	Geomaps int
+	Maps    int
+	Lines   int
+	Text    int
+	Symbols int
+	// Diagnostics is the number of video map files that couldn't be used.
+	Diagnostics int
+	Elapsed     time.Duration
+	Err         error
+}
+
+// discoverARTCCs returns the IDs of all ARTCCs in the CRC directory,
+// sorted alphabetically.
+func discoverARTCCs(crcDir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(crcDir, "ARTCCs", "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, f := range files {
+		ids = append(ids, strings.TrimSuffix(filepath.Base(f), ".json"))
+	}
+	slices.Sort(ids)
+	return ids, nil
+}
+
+// runBatch processes every ARTCC in the CRC directory using up to jobs
+// concurrent workers. Failures are collected rather than aborting the
+// batch; the number of ARTCCs that failed is returned.
+func runBatch(crcDir string, opts options, jobs int) int {
+	ids, err := discoverARTCCs(crcDir)
+	if err != nil {
+		log.Printf("Error finding ARTCC files: %v", err)
+		return 1
+	}
+	if len(ids) == 0 {
+		log.Printf("Error: no ARTCC files found in %s", filepath.Join(crcDir, "ARTCCs"))
+		return 1
+	}
+	jobs = max(1, min(jobs, len(ids)))
+	log.Printf("Processing %d ARTCCs with %d workers", len(ids), jobs)
+
+	summaries := make([]artccSummary, len(ids))
+	work := make(chan int)
+	var wg sync.WaitGroup
+	for range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				id := ids[i]
+				lg := log.New(log.Writer(), "["+id+"] ", log.Flags()|log.Lmsgprefix)
+
+				start := time.Now()
+				summary, err := processARTCC(crcDir, id, opts, lg)
+				summary.ID = id
+				summary.Elapsed = time.Since(start)
+				summary.Err = err
+				if err != nil {
+					lg.Printf("Error: %v", err)
+				}
+				summaries[i] = summary
+			}
+		}()
+	}
+	for i := range ids {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+
+	return writeBatchSummary(summaries)
+}
+
+// writeBatchSummary prints a per-ARTCC table of results to stdout and
+// returns the number of failures.
+func writeBatchSummary(summaries []artccSummary) int {
+	failed := 0
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ARTCC\tSTATUS\tGEOMAPS\tMAPS\tLINES\tTEXT\tSYMBOLS\tDIAGNOSTICS\tTIME")
+	for _, s := range summaries {
+		status := "ok"
+		if s.Err != nil {
+			status = "FAILED"
+			failed++
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\t%d\t%d\t%d\t%s\n", s.ID, status, s.Geomaps, s.Maps, s.Lines,
+			s.Text, s.Symbols, s.Diagnostics, s.Elapsed.Round(time.Millisecond))
+	}
+	tw.Flush()
+
+	for _, s := range summaries {
+		if s.Err != nil {
+			fmt.Printf("%s: %v\n", s.ID, s.Err)
+		}
+	}
+	return failed
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// BeaconCodeExport holds the beacon code banks of an ARTCC's ERAM and of
+// each of its TRACONs' STARS, in the form of vice's squawk code
+// assignment configuration, along with any problems found in them.
+type BeaconCodeExport struct {
+	Facility string                `json:"facility"`
+	ERAM     []BeaconCodeBank      `json:"eram_banks"`
+	STARS    []STARSBeaconCodeBank `json:"stars_banks"`
+	Problems []string              `json:"problems"`
+}
+
+// BeaconCodeBank is a range of squawk codes. Codes are four octal
+// digits: the bank's two-digit subset followed by two digits within it.
+type BeaconCodeBank struct {
+	ID       string `json:"id"`
+	Category string `json:"category,omitempty"` // ERAM only
+	Priority string `json:"priority,omitempty"` // ERAM only
+	Type     string `json:"type,omitempty"`     // STARS only
+	First    string `json:"first"`
+	Last     string `json:"last"`
+	Count    int    `json:"count"`
+}
+
+type STARSBeaconCodeBank struct {
+	Facility string           `json:"facility"`
+	Banks    []BeaconCodeBank `json:"banks"`
+}
+
+// codeRange is a validated bank's inclusive range of codes.
+type codeRange struct {
+	facility, id string
+	first, last  int
+}
+
+func exportBeaconCodes(ctx *exportContext) (any, error) {
+	artcc := ctx.artcc
+	out := BeaconCodeExport{
+		Facility: artcc.ID,
+		ERAM:     []BeaconCodeBank{},
+		STARS:    []STARSBeaconCodeBank{},
+		Problems: []string{},
+	}
+	problem := func(format string, args ...any) {
+		p := fmt.Sprintf(format, args...)
+		ctx.lg.Printf("  Warning: %s", p)
+		out.Problems = append(out.Problems, p)
+	}
+
+	var center []codeRange
+	for _, b := range artcc.Facility.EramConfiguration.BeaconCodeBanks {
+		r, err := beaconCodeRange(b.Subset, b.Start, b.End)
+		if err != nil {
+			problem("%s ERAM bank %s: %v", artcc.ID, b.ID, err)
+			continue
+		}
+		center = append(center, codeRange{artcc.ID, b.ID, r[0], r[1]})
+		out.ERAM = append(out.ERAM, BeaconCodeBank{
+			ID:       b.ID,
+			Category: b.Category,
+			Priority: b.Priority,
+			First:    squawk(r[0]),
+			Last:     squawk(r[1]),
+			Count:    r[1] - r[0] + 1,
+		})
+	}
+
+	for _, child := range artcc.Facility.ChildFacilities {
+		banks := child.StarsConfiguration.BeaconCodeBanks
+		if len(banks) == 0 {
+			continue
+		}
+
+		sb := STARSBeaconCodeBank{Facility: child.ID, Banks: []BeaconCodeBank{}}
+		for _, b := range banks {
+			r, err := beaconCodeRange(b.Subset, b.Start, b.End)
+			if err != nil {
+				problem("%s STARS bank %s: %v", child.ID, b.ID, err)
+				continue
+			}
+			// A TRACON's codes are assigned by the center's ERAM too, so
+			// they mustn't be handed out by both.
+			for _, c := range center {
+				if r[0] <= c.last && c.first <= r[1] {
+					problem("%s STARS bank %s (%s-%s) overlaps %s ERAM bank %s (%s-%s)",
+						child.ID, b.ID, squawk(r[0]), squawk(r[1]),
+						c.facility, c.id, squawk(c.first), squawk(c.last))
+				}
+			}
+			sb.Banks = append(sb.Banks, BeaconCodeBank{
+				ID:    b.ID,
+				Type:  b.Type,
+				First: squawk(r[0]),
+				Last:  squawk(r[1]),
+				Count: r[1] - r[0] + 1,
+			})
+		}
+		out.STARS = append(out.STARS, sb)
+	}
+
+	ctx.lg.Printf("  %d ERAM beacon code bank(s), %d TRACON(s) with STARS banks, %d problem(s)",
+		len(out.ERAM), len(out.STARS), len(out.Problems))
+	if ctx.opts.strict && len(out.Problems) > 0 {
+		ctx.strictErrs = append(ctx.strictErrs, fmt.Errorf("%d beacon code bank problem(s)", len(out.Problems)))
+	}
+	return out, nil
+}
+
+// beaconCodeRange returns the first and last codes of a bank given its
+// subset and start and end codes within the subset. CRC writes these as
+// the decimal numbers that read the same as their octal digits, so 42
+// is subset 042.
+func beaconCodeRange(subset, start, end float32) ([2]int, error) {
+	s, err := octalDigits("subset", subset)
+	if err != nil {
+		return [2]int{}, err
+	}
+	first, err := octalDigits("start", start)
+	if err != nil {
+		return [2]int{}, err
+	}
+	last, err := octalDigits("end", end)
+	if err != nil {
+		return [2]int{}, err
+	}
+	if first > last {
+		return [2]int{}, fmt.Errorf("start %02o is after end %02o", first, last)
+	}
+	return [2]int{s<<6 | first, s<<6 | last}, nil
+}
+
+// octalDigits returns the value of a number of at most two octal digits.
+func octalDigits(what string, f float32) (int, error) {
+	if f < 0 || f > 77 || f != float32(int(f)) {
+		return 0, fmt.Errorf("%s %v is not a two-digit octal number", what, f)
+	}
+	v, err := strconv.ParseInt(strconv.Itoa(int(f)), 8, 0)
+	if err != nil {
+		return 0, fmt.Errorf("%s %v is not a two-digit octal number", what, f)
+	}
+	return int(v), nil
+}
+
+func squawk(code int) string {
+	return fmt.Sprintf("%04o", code)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// crcDirCandidates returns the places where CRC is usually installed, in
+// the order they should be tried: the native Windows location and then
+// Wine prefixes on Linux and macOS.
+func crcDirCandidates() []string {
+	var dirs []string
+	if runtime.GOOS == "windows" {
+		if local := os.Getenv("LOCALAPPDATA"); local != "" {
+			dirs = append(dirs, filepath.Join(local, "CRC"))
+		}
+		return dirs
+	}
+
+	var prefixes []string
+	if prefix := os.Getenv("WINEPREFIX"); prefix != "" {
+		prefixes = append(prefixes, prefix)
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		prefixes = append(prefixes, filepath.Join(home, ".wine"))
+	}
+	for _, prefix := range prefixes {
+		// Wine uses the Unix user name for the Windows profile, but
+		// check all profiles in case the prefix was created elsewhere.
+		if user := os.Getenv("USER"); user != "" {
+			dirs = append(dirs, filepath.Join(prefix, "drive_c", "users", user, "AppData", "Local", "CRC"))
+		}
+		if m, err := filepath.Glob(filepath.Join(prefix, "drive_c", "users", "*", "AppData", "Local", "CRC")); err == nil {
+			dirs = append(dirs, m...)
+		}
+	}
+	return dirs
+}
+
+// findCRCDir returns the CRC directory to use when -crc-dir isn't given:
+// the working directory if it looks like a CRC directory (the historical
+// behavior) and otherwise the first default install location that does.
+func findCRCDir() (string, error) {
+	if wd, err := os.Getwd(); err == nil && validateCRCDir(wd) == nil {
+		return wd, nil
+	}
+	for _, dir := range crcDirCandidates() {
+		if validateCRCDir(dir) == nil {
+			return dir, nil
+		}
+	}
+	return "", errors.New("unable to find the CRC directory; run from the CRC folder or specify it with -crc-dir")
+}
+
+// validateCRCDir checks that dir contains the ARTCCs/ and VideoMaps/
+// directories that CRC downloads facility data into.
+func validateCRCDir(dir string) error {
+	for _, sub := range []string{"ARTCCs", "VideoMaps"} {
+		if err := checkDir(filepath.Join(dir, sub)); err != nil {
+			return fmt.Errorf("%s does not appear to be a CRC directory: %w", dir, err)
+		}
+	}
+	return nil
+}
+
+// checkDir returns an error if path doesn't exist or isn't a directory.
+func checkDir(path string) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%s: directory not found", path)
+		}
+		return err
+	}
+	if !fi.IsDir() {
+		return fmt.Errorf("%s: not a directory", path)
+	}
+	return nil
+}
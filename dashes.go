@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DashPattern is a sequence of alternating dash and gap lengths, in
+// nautical miles, starting with a dash. It implements flag.Value so that
+// patterns can be set from the command line as e.g. "1,1".
+type DashPattern []float64
+
+func (p *DashPattern) String() string {
+	if p == nil {
+		return ""
+	}
+	var s []string
+	for _, v := range *p {
+		s = append(s, strconv.FormatFloat(v, 'g', -1, 64))
+	}
+	return strings.Join(s, ",")
+}
+
+func (p *DashPattern) Set(s string) error {
+	var pat DashPattern
+	for _, f := range strings.Split(s, ",") {
+		v, err := strconv.ParseFloat(strings.TrimSpace(f), 64)
+		if err != nil {
+			return fmt.Errorf("%s: invalid length: %v", f, err)
+		}
+		if v < 0 {
+			return fmt.Errorf("%s: lengths must not be negative", f)
+		}
+		pat = append(pat, v)
+	}
+	if len(pat) < 2 || len(pat)%2 != 0 {
+		return fmt.Errorf("%s: pattern must have an even number of dash,gap lengths", s)
+	}
+	if pat[0] <= 0 {
+		return fmt.Errorf("%s: pattern must start with a dash longer than zero", s)
+	}
+	*p = pat
+	return nil
+}
+
+// defaultDashPatterns returns the dash patterns used when dashes are
+// baked into the output. The short and long lengths match the 1' and 2'
+// of latitude that were used before dashes were measured in nautical
+// miles; long-short alternates the two. Solid lines have no pattern.
+func defaultDashPatterns() map[LineStyle]*DashPattern {
+	return map[LineStyle]*DashPattern{
+		LineStyleShortDashed:     {1, 1},
+		LineStyleLongDashed:      {2, 2},
+		LineStyleLongShortDashed: {2, 1, 1, 1},
+	}
+}
+
+// nmPerDegreeLatitude is the length of one degree of latitude in nautical
+// miles; a degree of longitude is shorter by cos(latitude).
+const nmPerDegreeLatitude = 60
+
+// nmDistance returns the distance between two points in nautical miles
+// using a local equirectangular projection, which is accurate enough for
+// the short distances between points in a facility.
+func nmDistance(a, b Point2LL) float64 {
+	lat1, lat2 := float64(a[1]), float64(b[1])
+	nmPerDegreeLongitude := nmPerDegreeLatitude * math.Cos((lat1+lat2)/2*math.Pi/180)
+	return math.Hypot(float64(b[0]-a[0])*nmPerDegreeLongitude, (lat2-lat1)*nmPerDegreeLatitude)
+}
+
+// buildDashedSegments splits the polyline coords into dashes following
+// pattern. Distances are measured in nautical miles using a local
+// equirectangular projection for each polyline segment, so dashes keep
+// the same length regardless of latitude or direction.
+func buildDashedSegments(coords []Point2LL, pattern *DashPattern) [][]Point2LL {
+	if len(coords) < 2 || pattern == nil || len(*pattern) < 2 {
+		return [][]Point2LL{coords}
+	}
+	pat := *pattern
+	total := 0.0
+	for _, v := range pat {
+		total += v
+	}
+	if total <= 0 || pat[0] <= 0 {
+		return [][]Point2LL{coords}
+	}
+
+	var segments [][]Point2LL
+	var cur []Point2LL
+	// Helper to emit and reset the current dash segment
+	emit := func() {
+		if len(cur) >= 2 {
+			// Copy to avoid aliasing
+			seg := make([]Point2LL, len(cur))
+			copy(seg, cur)
+			segments = append(segments, seg)
+		}
+		cur = cur[:0]
+	}
+
+	// State: index into the pattern (even indices are dashes) and the
+	// distance left in the current element.
+	idx := 0
+	remaining := pat[0]
+
+	for i := 0; i < len(coords)-1; i++ {
+		lon1, lat1 := float64(coords[i][0]), float64(coords[i][1])
+		lon2, lat2 := float64(coords[i+1][0]), float64(coords[i+1][1])
+
+		segLen := nmDistance(coords[i], coords[i+1])
+		if segLen == 0 {
+			continue
+		}
+
+		at := func(d float64) Point2LL {
+			t := d / segLen
+			return Point2LL{float32(lon1 + t*(lon2-lon1)), float32(lat1 + t*(lat2-lat1))}
+		}
+
+		if idx%2 == 0 && len(cur) == 0 {
+			cur = append(cur, coords[i])
+		}
+
+		traveled := 0.0
+		for traveled < segLen {
+			step := math.Min(remaining, segLen-traveled)
+			traveled += step
+			remaining -= step
+
+			if remaining > 1e-9 {
+				// Reached the end of this input segment mid-element; keep
+				// the vertex so dashes follow the polyline around corners.
+				if idx%2 == 0 {
+					cur = append(cur, coords[i+1])
+				}
+				break
+			}
+
+			// Finished the current dash or gap; move to the next one,
+			// skipping any zero-length elements.
+			p := at(traveled)
+			if idx%2 == 0 {
+				cur = append(cur, p)
+				emit()
+			}
+			for {
+				idx = (idx + 1) % len(pat)
+				remaining = pat[idx]
+				if remaining > 0 {
+					break
+				}
+			}
+			if idx%2 == 0 {
+				cur = append(cur, p)
+			}
+		}
+	}
+
+	// If we ended while on a dash, emit it
+	if idx%2 == 0 {
+		emit()
+	}
+	if len(segments) == 0 {
+		return [][]Point2LL{coords}
+	}
+	return segments
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// dashTolerance is the allowed relative error in dash and gap lengths.
+const dashTolerance = 0.01
+
+func TestBuildDashedSegmentsLengths(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		from, to Point2LL
+	}{
+		{"25N east-west", Point2LL{-80, 25}, Point2LL{-79.5, 25}},
+		{"25N north-south", Point2LL{-80, 25}, Point2LL{-80, 25.5}},
+		{"65N east-west", Point2LL{-150, 65}, Point2LL{-149, 65}},
+		{"65N north-south", Point2LL{-150, 65}, Point2LL{-150, 65.5}},
+	} {
+		for _, pat := range []DashPattern{{1, 1}, {2, 2}, {2, 1, 1, 1}} {
+			t.Run(tc.name+"/"+pat.String(), func(t *testing.T) {
+				segs := buildDashedSegments([]Point2LL{tc.from, tc.to}, &pat)
+				checkDashes(t, segs, pat)
+			})
+		}
+	}
+}
+
+func TestBuildDashedSegmentsZeroLengthElements(t *testing.T) {
+	// A zero-length gap joins the two dashes around it end to end.
+	pat := DashPattern{2, 0, 1, 1}
+	segs := buildDashedSegments([]Point2LL{{-80, 25}, {-79.5, 25}}, &pat)
+	checkDashes(t, segs, pat)
+
+	// Without a leading dash there's nothing to draw, so the line is
+	// left solid. Set rejects such patterns.
+	pat = DashPattern{0, 1}
+	line := []Point2LL{{-80, 25}, {-79.5, 25}}
+	if segs := buildDashedSegments(line, &pat); len(segs) != 1 || !slices.Equal(segs[0], line) {
+		t.Errorf("pattern %s: got %d segments, expected the line unchanged", pat.String(), len(segs))
+	}
+}
+
+// checkDashes checks that consecutive segments and the gaps between them
+// follow pat. The final dash may be cut short by the end of the line.
+func checkDashes(t *testing.T, segs [][]Point2LL, pat DashPattern) {
+	t.Helper()
+	if len(segs) < 4 {
+		t.Fatalf("got %d segments, expected a line long enough for several dashes", len(segs))
+	}
+
+	// Zero-length elements are skipped, so dashes follow the nonzero
+	// elements in order.
+	idx := 0
+	for i, seg := range segs[:len(segs)-1] {
+		checkLength(t, "dash", i, polylineLength(seg), pat[idx])
+		gap := pat[idx+1]
+		checkLength(t, "gap", i, nmDistance(seg[len(seg)-1], segs[i+1][0]), gap)
+		idx = (idx + 2) % len(pat)
+	}
+}
+
+func checkLength(t *testing.T, what string, i int, got, expected float64) {
+	t.Helper()
+	if math.Abs(got-expected) > dashTolerance*math.Max(expected, 1) {
+		t.Errorf("%s %d: length %.4f nm, expected %.4f nm", what, i, got, expected)
+	}
+}
+
+func polylineLength(p []Point2LL) float64 {
+	d := 0.0
+	for i := 1; i < len(p); i++ {
+		d += nmDistance(p[i-1], p[i])
+	}
+	return d
+}
+
+func TestDashPatternSet(t *testing.T) {
+	for _, s := range []string{"", "a,1", "1", "1,2,3", "-1,1", "1,-1", "0,1", "0,0"} {
+		var p DashPattern
+		if err := p.Set(s); err == nil {
+			t.Errorf("%q: expected an error, got pattern %s", s, p.String())
+		}
+	}
+
+	var p DashPattern
+	if err := p.Set("1.5, 0.5,0,2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(p, DashPattern{1.5, 0.5, 0, 2}) {
+		t.Errorf("got %v, expected [1.5 0.5 0 2]", p)
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// Diagnostic records a video map file that couldn't be used, along with
+// the geomap that referenced it and the geomap's filters that would have
+// drawn from it. A file is reported once per geomap.
+type Diagnostic struct {
+	Geomap     string `json:"geomap,omitempty"`
+	Filters    []int  `json:"filters,omitempty"`
+	VideoMapID string `json:"videoMapId"`
+	Error      string `json:"error"`
+}
+
+// diagnosedFiles returns the number of distinct video map files in diags.
+func diagnosedFiles(diags []Diagnostic) int {
+	files := make(map[string]bool)
+	for _, d := range diags {
+		files[d.VideoMapID] = true
+	}
+	return len(files)
+}
+
+// writeDiagnostics writes the diagnostics as JSON to fn. An empty list is
+// still written so that consumers can tell a clean run from a missing
+// report.
+func writeDiagnostics(fn string, diags []Diagnostic) error {
+	if diags == nil {
+		diags = []Diagnostic{}
+	}
+
+	f, err := os.Create(fn)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(diags); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
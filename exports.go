@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// exportContext holds what an exporter needs to convert part of an ARTCC.
+type exportContext struct {
+	artcc *ARTCC
+	cache *videoMapCache
+	opts  options
+	lg    *log.Logger
+
+	// strictErrs collects problems that fail the run with -strict. They
+	// are reported once all of the output has been written, so that it
+	// can be inspected.
+	strictErrs []error
+}
+
+// exporter converts one part of the ARTCC's CRC configuration into a
+// vice-ready structure that is written as <ARTCC>-<name>.json and gob.
+type exporter struct {
+	name  string
+	build func(ctx *exportContext) (any, error)
+}
+
+// exporters lists the exports that can be requested with -export, in the
+// order they are written.
+var exporters = []exporter{
+	{name: "positions", build: exportPositions},
+	{name: "stars-areas", build: exportSTARSAreas},
+	{name: "tower", build: exportTowers},
+	{name: "auto-atc", build: exportAutoATCRules},
+	{name: "beacon-codes", build: exportBeaconCodes},
+	{name: "handoffs", build: exportHandoffs},
+	{name: "approach-volumes", build: exportApproachVolumes},
+}
+
+// exportSet is the set of exporters requested with -export. It
+// implements flag.Value and accepts a comma-separated list of names or
+// "all".
+type exportSet map[string]bool
+
+func (e exportSet) String() string {
+	return strings.Join(slices.Sorted(maps.Keys(e)), ",")
+}
+
+func (e exportSet) Set(s string) error {
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if name == "all" {
+			for _, ex := range exporters {
+				e[ex.name] = true
+			}
+			continue
+		}
+		if !slices.ContainsFunc(exporters, func(ex exporter) bool { return ex.name == name }) {
+			return fmt.Errorf("%s: unknown export; valid exports are %s", name, exporterNames())
+		}
+		e[name] = true
+	}
+	return nil
+}
+
+func exporterNames() string {
+	var names []string
+	for _, ex := range exporters {
+		names = append(names, ex.name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// runExports writes each of the exports requested in opts for the ARTCC.
+func runExports(artccID string, ctx *exportContext) error {
+	for _, ex := range exporters {
+		if !ctx.opts.exports[ex.name] {
+			continue
+		}
+
+		v, err := ex.build(ctx)
+		if err != nil {
+			return fmt.Errorf("%s export: %w", ex.name, err)
+		}
+
+		base := filepath.Join(ctx.opts.outDir, artccID+"-"+ex.name)
+		ctx.lg.Printf("Writing %s export to %s...", ex.name, gobFilename(base, !ctx.opts.rawGob))
+		if err := writeJSONAndGob(base, v, !ctx.opts.rawGob); err != nil {
+			return fmt.Errorf("writing %s export: %w", ex.name, err)
+		}
+	}
+	return nil
+}
@@ -1,3 +1,5 @@
 module github.com/checkandmate1/crc2vice-eram
 
 go 1.23.1
+
+require github.com/klauspost/compress v1.18.0
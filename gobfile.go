@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// zstdMagic is the magic number at the start of every zstd frame.
+var zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+// gobFilename returns the name of the gob file for base, adding the
+// ".zst" suffix that vice uses for compressed resources.
+func gobFilename(base string, compress bool) string {
+	if compress {
+		return base + ".gob.zst"
+	}
+	return base + ".gob"
+}
+
+// writeGob gob-encodes v to fn, zstd-compressing it if compress is set.
+func writeGob(fn string, v any, compress bool) error {
+	f, err := os.Create(fn)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = f
+	var zw *zstd.Encoder
+	if compress {
+		if zw, err = zstd.NewWriter(f, zstd.WithEncoderLevel(zstd.SpeedBetterCompression)); err != nil {
+			f.Close()
+			return err
+		}
+		w = zw
+	}
+
+	if err := gob.NewEncoder(w).Encode(v); err != nil {
+		f.Close()
+		return err
+	}
+	if zw != nil {
+		if err := zw.Close(); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	return f.Close()
+}
+
+// writeJSONAndGob writes v both as JSON to base+".json" and as a gob to
+// the gob file for base.
+func writeJSONAndGob(base string, v any, compress bool) error {
+	jf, err := os.Create(base + ".json")
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(jf).Encode(v); err != nil {
+		jf.Close()
+		return fmt.Errorf("writing json payload: %w", err)
+	}
+	if err := jf.Close(); err != nil {
+		return err
+	}
+
+	if err := writeGob(gobFilename(base, compress), v, compress); err != nil {
+		return fmt.Errorf("writing gob payload: %w", err)
+	}
+	return nil
+}
+
+// readGob decodes the gob in fn into v. Whether the file is zstd
+// compressed is detected from its contents rather than its name.
+func readGob(fn string, v any) error {
+	f, err := os.Open(fn)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	br := bufio.NewReader(f)
+	var r io.Reader = br
+	if magic, err := br.Peek(len(zstdMagic)); err == nil && bytes.Equal(magic, zstdMagic) {
+		zr, err := zstd.NewReader(br)
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+		r = zr
+	}
+
+	return gob.NewDecoder(r).Decode(v)
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "Update the golden files in testdata/golden")
+
+const testCRCDir = "testdata/crc"
+
+// goldenExports lists the exports that have golden files.
+var goldenExports = []string{"positions", "stars-areas", "tower", "auto-atc", "beacon-codes", "handoffs", "approach-volumes"}
+
+// testOptions returns the options used for the fixture ARTCC, with the
+// given exports requested.
+func testOptions(outDir string, exports ...string) options {
+	opts := options{outDir: outDir, dashPatterns: defaultDashPatterns(), exports: make(exportSet)}
+	for _, name := range exports {
+		if err := opts.exports.Set(name); err != nil {
+			panic(err)
+		}
+	}
+	return opts
+}
+
+func runTestARTCC(t *testing.T, opts options) {
+	t.Helper()
+	if _, err := processARTCC(testCRCDir, "ZXX", opts, log.New(io.Discard, "", 0)); err != nil {
+		t.Fatalf("processARTCC: %v", err)
+	}
+}
+
+// readOutputs returns the contents of each file in dir by name.
+func readOutputs(t *testing.T, dir string) map[string][]byte {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string][]byte)
+	for _, e := range entries {
+		b, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[e.Name()] = b
+	}
+	return files
+}
+
+// TestReproducibleOutput runs the converter twice on the fixture ARTCC
+// and checks that every JSON and gob file is byte-for-byte identical.
+func TestReproducibleOutput(t *testing.T) {
+	dirs := []string{t.TempDir(), t.TempDir()}
+	for _, dir := range dirs {
+		runTestARTCC(t, testOptions(dir, "all"))
+	}
+
+	first, second := readOutputs(t, dirs[0]), readOutputs(t, dirs[1])
+	if len(first) == 0 {
+		t.Fatal("no output files were written")
+	}
+	for _, name := range []string{"ZXX-eram-videomaps.json", "ZXX-eram-videomaps.gob.zst", "ZXX-eram-manifest.gob.zst"} {
+		if _, ok := first[name]; !ok {
+			t.Errorf("%s: not written", name)
+		}
+	}
+	for name, b := range first {
+		if !bytes.Equal(b, second[name]) {
+			t.Errorf("%s: output differs between runs", name)
+		}
+	}
+	for name := range second {
+		if _, ok := first[name]; !ok {
+			t.Errorf("%s: only written by the second run", name)
+		}
+	}
+}
+
+// TestStrictWritesAllOutput checks that with -strict, the fixture's
+// unusable video maps and beacon code bank problems fail the run only
+// after every output file has been written.
+func TestStrictWritesAllOutput(t *testing.T) {
+	dir := t.TempDir()
+	opts := testOptions(dir, "all")
+	opts.strict = true
+	_, err := processARTCC(testCRCDir, "ZXX", opts, log.New(io.Discard, "", 0))
+	if err == nil || !strings.Contains(err.Error(), "video map file(s)") || !strings.Contains(err.Error(), "beacon code bank") {
+		t.Fatalf("got error %v, expected video map and beacon code bank problems", err)
+	}
+
+	outputs := readOutputs(t, dir)
+	for _, ex := range exporters {
+		if _, ok := outputs["ZXX-"+ex.name+".json"]; !ok {
+			t.Errorf("%s export: not written", ex.name)
+		}
+	}
+	if _, ok := outputs["ZXX-eram-diagnostics.json"]; !ok {
+		t.Error("diagnostics: not written")
+	}
+}
+
+// TestGoldenOutput compares the JSON output for the fixture ARTCC, with
+// the exports in goldenExports, with testdata/golden. Run with -update
+// after intentional output changes.
+func TestGoldenOutput(t *testing.T) {
+	dir := t.TempDir()
+	runTestARTCC(t, testOptions(dir, goldenExports...))
+	compareGolden(t, readOutputs(t, dir))
+}
+
+// TestSTARSGoldenOutput compares the JSON output of -stars for the
+// fixture TRACON, whose missing vm3 is reported as a diagnostic, with
+// testdata/golden.
+func TestSTARSGoldenOutput(t *testing.T) {
+	dir := t.TempDir()
+	if err := processSTARS(testCRCDir, "ZXX", "XTR", testOptions(dir), log.New(io.Discard, "", 0)); err != nil {
+		t.Fatalf("processSTARS: %v", err)
+	}
+	compareGolden(t, readOutputs(t, dir))
+}
+
+// compareGolden compares each JSON file in outputs with the file of the
+// same name in testdata/golden, or updates the golden files with -update.
+func compareGolden(t *testing.T, outputs map[string][]byte) {
+	t.Helper()
+	goldenDir := filepath.Join("testdata", "golden")
+	if *update {
+		if err := os.MkdirAll(goldenDir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(outputs)) {
+		if filepath.Ext(name) != ".json" {
+			continue
+		}
+		fn := filepath.Join(goldenDir, name)
+		if *update {
+			if err := os.WriteFile(fn, outputs[name], 0o644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+
+		golden, err := os.ReadFile(fn)
+		if err != nil {
+			t.Errorf("%s: %v (run with -update to create it)", name, err)
+			continue
+		}
+		if !bytes.Equal(outputs[name], golden) {
+			t.Errorf("%s: output differs from %s", name, fn)
+		}
+	}
+}
+
+// TestBakeDashes checks that with -bake-dashes every line of the fixture
+// ARTCC is solid and that dash segments keep the BCG of their line.
+func TestBakeDashes(t *testing.T) {
+	read := func(bake bool) ERAMMapGroups {
+		dir := t.TempDir()
+		opts := testOptions(dir)
+		opts.bakeDashes = bake
+		runTestARTCC(t, opts)
+		b, err := os.ReadFile(filepath.Join(dir, "ZXX-eram-videomaps.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var groups ERAMMapGroups
+		if err := json.Unmarshal(b, &groups); err != nil {
+			t.Fatal(err)
+		}
+		return groups
+	}
+	bcgs := func(lines []ERAMLine) []string {
+		var b []string
+		for _, l := range lines {
+			b = append(b, l.BcgName)
+		}
+		slices.Sort(b)
+		return slices.Compact(b)
+	}
+
+	styled, baked := read(false), read(true)
+	for i := range styled {
+		for j, m := range styled[i].Maps {
+			bm := baked[i].Maps[j]
+			if len(bm.StyledLines) < len(m.StyledLines) {
+				t.Errorf("%s filter %d: got %d baked lines, expected at least %d", m.Name, m.Filter, len(bm.StyledLines), len(m.StyledLines))
+			}
+			for _, l := range bm.StyledLines {
+				if l.Style != LineStyleSolid {
+					t.Errorf("%s filter %d: baked line has style %s", m.Name, m.Filter, l.Style)
+				}
+			}
+			if got, expected := bcgs(bm.StyledLines), bcgs(m.StyledLines); !slices.Equal(got, expected) {
+				t.Errorf("%s filter %d: baked lines have BCGs %q, expected %q", m.Name, m.Filter, got, expected)
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import "slices"
+
+// HandoffExport holds the interfacility handoff identities between an
+// ARTCC and the STARS facilities it hands off to, as a vice adaptation
+// fragment.
+type HandoffExport struct {
+	Facility string `json:"facility"`
+	// STARSNeighbors is how the center's ERAM identifies each
+	// neighboring STARS facility.
+	STARSNeighbors []STARSNeighbor `json:"stars_neighbors"`
+	// TRACONs lists the handoff numbers each TRACON uses to address
+	// other facilities.
+	TRACONs []TRACONHandoffIDs `json:"tracons"`
+}
+
+type STARSNeighbor struct {
+	Facility     string `json:"facility"`
+	STARSID      string `json:"stars_id"`
+	SingleCharID string `json:"single_char_id,omitempty"`
+	FieldEFormat string `json:"field_e_format"`
+	FieldELetter string `json:"field_e_letter,omitempty"`
+}
+
+type TRACONHandoffIDs struct {
+	Facility   string      `json:"facility"`
+	HandoffIDs []HandoffID `json:"handoff_ids"`
+}
+
+type HandoffID struct {
+	Facility string `json:"facility"`
+	Number   int    `json:"number"`
+}
+
+func exportHandoffs(ctx *exportContext) (any, error) {
+	artcc := ctx.artcc
+	out := HandoffExport{
+		Facility:       artcc.ID,
+		STARSNeighbors: []STARSNeighbor{},
+		TRACONs:        []TRACONHandoffIDs{},
+	}
+
+	for _, n := range artcc.Facility.EramConfiguration.NeighboringStarsConfigurations {
+		out.STARSNeighbors = append(out.STARSNeighbors, STARSNeighbor{
+			Facility:     n.FacilityID,
+			STARSID:      n.StarsID,
+			SingleCharID: n.SingleCharacterStarsID,
+			FieldEFormat: n.FieldEFormat,
+			FieldELetter: n.FieldELetter,
+		})
+	}
+
+	for _, child := range artcc.Facility.ChildFacilities {
+		ids := child.StarsConfiguration.StarsHandoffIds
+		if len(ids) == 0 {
+			continue
+		}
+
+		// Handoffs from the center to a TRACON it doesn't list as a
+		// neighbor can't show the TRACON's identity.
+		if !slices.ContainsFunc(out.STARSNeighbors, func(n STARSNeighbor) bool { return n.Facility == child.ID }) {
+			ctx.lg.Printf("  Warning: %s is not a neighboring STARS facility of %s", child.ID, artcc.ID)
+		}
+
+		t := TRACONHandoffIDs{Facility: child.ID, HandoffIDs: []HandoffID{}}
+		for _, id := range ids {
+			t.HandoffIDs = append(t.HandoffIDs, HandoffID{Facility: id.FacilityID, Number: int(id.HandoffNumber)})
+		}
+		out.TRACONs = append(out.TRACONs, t)
+	}
+
+	ctx.lg.Printf("  %d neighboring STARS facilities, %d TRACON(s) with handoff IDs",
+		len(out.STARSNeighbors), len(out.TRACONs))
+	return out, nil
+}
@@ -0,0 +1,43 @@
+package main
+
+// LineStyle is the normalized form of a CRC ERAM line style; consumers
+// are expected to render the dash pattern themselves at scope scale.
+type LineStyle int
+
+const (
+	LineStyleSolid LineStyle = iota
+	LineStyleShortDashed
+	LineStyleLongDashed
+	LineStyleLongShortDashed
+)
+
+func (s LineStyle) String() string {
+	switch s {
+	case LineStyleShortDashed:
+		return "ShortDashed"
+	case LineStyleLongDashed:
+		return "LongDashed"
+	case LineStyleLongShortDashed:
+		return "LongShortDashed"
+	default:
+		return "Solid"
+	}
+}
+
+// parseLineStyle maps a CRC line style string to a LineStyle. An empty
+// style is solid; anything else that is unrecognized is also drawn solid
+// but ok is false so that the caller can report it.
+func parseLineStyle(s string) (style LineStyle, ok bool) {
+	switch normalizeStyle(s) {
+	case "", "solid":
+		return LineStyleSolid, true
+	case "shortdashed", "shortdash", "dashed":
+		return LineStyleShortDashed, true
+	case "longdashed", "longdash":
+		return LineStyleLongDashed, true
+	case "longdashshortdash", "longshortdashed", "longshortdash":
+		return LineStyleLongShortDashed, true
+	default:
+		return LineStyleSolid, false
+	}
+}
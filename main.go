@@ -1,388 +1,440 @@
 package main
 
 import (
-	"encoding/gob"
 	"encoding/json"
+	"errors"
 	"flag"
+	"fmt"
 	"log"
-	"math"
+	"maps"
 	"os"
 	"path/filepath"
+	"runtime"
 	"slices"
 	"strconv"
 	"strings"
 )
 
+// options holds the settings that apply to every ARTCC processed.
+type options struct {
+	outDir           string
+	strict           bool
+	rawGob           bool
+	keepBlankFilters bool
+	bakeDashes       bool
+	dashPatterns     map[LineStyle]*DashPattern
+	exports          exportSet
+	// For the approach-volumes export.
+	approachDebugMaps bool
+	magVar            magVarFlag
+}
+
 func main() {
 	log.Println("=== CRC ERAM Map Processor Starting ===")
 
 	var inputARTCC string
+	var all bool
+	var starsTRACON string
+	var jobs int
+	opts := options{dashPatterns: defaultDashPatterns(), exports: make(exportSet), magVar: make(magVarFlag)}
+	var crcDir string
 	flag.StringVar(&inputARTCC, "artcc", "", "ARTCC to get files for")
+	flag.StringVar(&crcDir, "crc-dir", "", "CRC directory containing ARTCCs/ and VideoMaps/ (default: current directory or CRC install location)")
+	flag.StringVar(&opts.outDir, "out-dir", ".", "Directory to write output files to")
+	flag.BoolVar(&opts.rawGob, "raw-gob", false, "Write uncompressed gob files instead of zstd-compressed ones")
+	flag.Var(opts.exports, "export", "Comma-separated list of additional exports to write, or \"all\" ("+exporterNames()+")")
+	flag.BoolVar(&opts.approachDebugMaps, "approach-debug-maps", false, "Include STARS video maps drawing the ATPA volumes and CRDA regions in the approach-volumes export (enables the export)")
+	flag.Var(opts.magVar, "mag-var", "Magnetic variation in degrees (east positive) used to draw ATPA volumes with -approach-debug-maps, for all TRACONs or as TRACON=variation,...")
+	flag.BoolVar(&opts.strict, "strict", false, "Fail if any video map file is missing or can't be decoded, or an export finds problems such as invalid beacon code banks; all output is still written")
+	flag.BoolVar(&all, "all", false, "Process every ARTCC in the ARTCCs directory")
+	flag.StringVar(&starsTRACON, "stars", "", "TRACON to convert STARS video maps for instead of ERAM maps (-artcc is optional)")
+	flag.IntVar(&jobs, "jobs", runtime.NumCPU(), "Number of ARTCCs to process concurrently with -all")
+	flag.BoolVar(&opts.keepBlankFilters, "keep-blank-filters", false, "Emit placeholder maps for blank or empty filters so filter positions match CRC")
+	flag.BoolVar(&opts.bakeDashes, "bake-dashes", false, "Pre-split dashed lines into solid segments (legacy output for older consumers)")
+	flag.Var(opts.dashPatterns[LineStyleShortDashed], "short-dash", "Short dash pattern for -bake-dashes as comma-separated dash,gap lengths in nm")
+	flag.Var(opts.dashPatterns[LineStyleLongDashed], "long-dash", "Long dash pattern for -bake-dashes as comma-separated dash,gap lengths in nm")
+	flag.Var(opts.dashPatterns[LineStyleLongShortDashed], "long-short-dash", "Long-short dash pattern for -bake-dashes as comma-separated dash,gap,... lengths in nm")
 	flag.Parse()
 
-	if inputARTCC == "" {
-		log.Fatal("Error: ARTCC parameter is required. Use -artcc flag to specify ARTCC (e.g., ZNY)")
+	if inputARTCC == "" && !all && starsTRACON == "" {
+		log.Fatal("Error: ARTCC parameter is required. Use -artcc flag to specify ARTCC (e.g., ZNY) or -all for every ARTCC")
+	}
+
+	if starsTRACON != "" && (all || len(opts.exports) > 0 || opts.approachDebugMaps) {
+		log.Fatal("Error: -stars converts a single TRACON's video maps and can't be combined with -all, -export or -approach-debug-maps")
+	}
+
+	if opts.approachDebugMaps {
+		// Magnetic variation differs from facility to facility, so it must
+		// be given for the TRACONs being drawn rather than assumed; TRACONs
+		// without a value fail their ARTCC.
+		if len(opts.magVar) == 0 {
+			log.Fatal("Error: -approach-debug-maps requires -mag-var")
+		}
+		// The debug maps are written as part of the approach-volumes
+		// export.
+		opts.exports["approach-volumes"] = true
+	}
+
+	if crcDir == "" {
+		var err error
+		if crcDir, err = findCRCDir(); err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+	} else if err := validateCRCDir(crcDir); err != nil {
+		log.Fatalf("Error: %v", err)
 	}
+	log.Printf("CRC directory: %s", crcDir)
 
-	log.Printf("Processing ARTCC: %s", inputARTCC)
+	if err := os.MkdirAll(opts.outDir, 0o755); err != nil {
+		log.Fatalf("Error creating output directory: %v", err)
+	}
+	log.Printf("Output directory: %s", opts.outDir)
 
-	// Assume were in the CRC directory.
-	currentDir, _ := os.Getwd()
-	log.Printf("Current working directory: %s", currentDir)
+	if starsTRACON != "" {
+		if err := processSTARS(crcDir, inputARTCC, starsTRACON, opts, log.Default()); err != nil {
+			log.Fatalf("Error processing %s: %v", starsTRACON, err)
+		}
+	} else if all {
+		if failed := runBatch(crcDir, opts, jobs); failed > 0 {
+			log.Fatalf("Error: %d ARTCC(s) failed", failed)
+		}
+	} else {
+		if _, err := processARTCC(crcDir, inputARTCC, opts, log.Default()); err != nil {
+			log.Fatalf("Error processing %s: %v", inputARTCC, err)
+		}
+	}
 
-	artccDir := filepath.Join(currentDir, "ARTCCs", inputARTCC+".json")
+	log.Println("=== CRC ERAM Map Processor Complete ===")
+}
 
-	log.Printf("ARTCC file path: %s", artccDir)
-	file, err := os.Open(artccDir)
+// loadARTCC reads and parses the ARTCC file for artccID in the CRC
+// directory.
+func loadARTCC(crcDir, artccID string) (*ARTCC, error) {
+	file, err := os.Open(filepath.Join(crcDir, "ARTCCs", artccID+".json"))
 	if err != nil {
-		log.Fatalf("Error opening ARTCC file: %v", err)
+		return nil, fmt.Errorf("opening ARTCC file: %w", err)
 	}
 	defer file.Close()
 
-	log.Println("Reading and parsing ARTCC file...")
-	artcc := ARTCC{}
-	err = json.NewDecoder(file).Decode(&artcc)
+	var artcc ARTCC
+	if err := json.NewDecoder(file).Decode(&artcc); err != nil {
+		return nil, fmt.Errorf("parsing ARTCC JSON file: %w", err)
+	}
+	return &artcc, nil
+}
+
+// processARTCC converts the ERAM video maps of a single ARTCC found in
+// the CRC directory crcDir and writes the output files.
+func processARTCC(crcDir, artccID string, opts options, lg *log.Logger) (artccSummary, error) {
+	summary := artccSummary{ID: artccID}
+
+	lg.Printf("Processing ARTCC: %s", artccID)
+
+	lg.Printf("ARTCC file path: %s", filepath.Join(crcDir, "ARTCCs", artccID+".json"))
+	lg.Println("Reading and parsing ARTCC file...")
+	artcc, err := loadARTCC(crcDir, artccID)
 	if err != nil {
-		log.Fatalf("Error parsing ARTCC JSON file: %v", err)
+		return summary, err
 	}
 
-	log.Printf("Successfully loaded ARTCC: %s (ID: %s)", artcc.Facility.Name, artcc.Facility.ID)
+	lg.Printf("Successfully loaded ARTCC: %s (ID: %s)", artcc.Facility.Name, artcc.Facility.ID)
+	summary.Geomaps = len(artcc.Facility.EramConfiguration.GeoMaps)
+
+	videoMapsDir := filepath.Join(crcDir, "VideoMaps", artccID)
+	if err := checkDir(videoMapsDir); err != nil {
+		return summary, fmt.Errorf("video maps for %s: %w", artccID, err)
+	}
 
 	output := ERAMMapGroups{}
+	cache := newVideoMapCache(videoMapsDir)
+	var diags []Diagnostic
 
-	log.Printf("Found %d geomaps in ERAM configuration", len(artcc.Facility.EramConfiguration.GeoMaps))
+	lg.Printf("Found %d geomaps in ERAM configuration", len(artcc.Facility.EramConfiguration.GeoMaps))
 
 	for i, geoMap := range artcc.Facility.EramConfiguration.GeoMaps {
-		log.Printf("Processing geomap %d/%d: %s (ID: %s)", i+1, len(artcc.Facility.EramConfiguration.GeoMaps), geoMap.Name, geoMap.ID)
-		log.Printf("  - Label: %s / %s", geoMap.LabelLine1, geoMap.LabelLine2)
-		log.Printf("  - Video map count: %d", len(geoMap.VideoMapIds))
-		log.Printf("  - BCG menu items: %d", len(geoMap.BcgMenu))
+		lg.Printf("Processing geomap %d/%d: %s (ID: %s)", i+1, len(artcc.Facility.EramConfiguration.GeoMaps), geoMap.Name, geoMap.ID)
+		lg.Printf("  - Label: %s / %s", geoMap.LabelLine1, geoMap.LabelLine2)
+		lg.Printf("  - Video map count: %d", len(geoMap.VideoMapIds))
+		lg.Printf("  - BCG menu items: %d", len(geoMap.BcgMenu))
+		// Check the geomap's video maps up front so that each bad file is
+		// reported once per geomap, even if no filter ends up using it.
+		badVideoMaps := make(map[string]int) // video map ID -> index in diags
+		for _, videoMapID := range geoMap.VideoMapIds {
+			if _, err := cache.Get(videoMapID); err != nil {
+				lg.Printf("  Warning: skipping video map %s: %v", videoMapID, err)
+				badVideoMaps[videoMapID] = len(diags)
+				diags = append(diags, Diagnostic{Geomap: geoMap.Name, VideoMapID: videoMapID, Error: err.Error()})
+			}
+		}
+
 		group := ERAMMapGroup{}
 		for j, filterMenu := range geoMap.FilterMenu {
 
-			log.Printf("  Processing filter menu %d/%d: %s %s", j+1, len(geoMap.FilterMenu), filterMenu.LabelLine1, filterMenu.LabelLine2)
+			lg.Printf("  Processing filter menu %d/%d: %s %s", j+1, len(geoMap.FilterMenu), filterMenu.LabelLine1, filterMenu.LabelLine2)
+
+			// placeholder is emitted in place of blank or empty filters
+			// when requested so that later filters keep their positions.
+			placeholder := ERAMMap{
+				LabelLine1:  filterMenu.LabelLine1,
+				LabelLine2:  filterMenu.LabelLine2,
+				Name:        geoMap.Name,
+				Filter:      j + 1,
+				Placeholder: true,
+			}
 
 			// Skip unnamed/blank filters
 			if filterMenu.LabelLine1 == "" && filterMenu.LabelLine2 == "" {
+				if opts.keepBlankFilters {
+					group.Maps = append(group.Maps, placeholder)
+				}
 				continue
 			}
 
-			// Aggregate lines across all video maps for this filter
-			var aggregatedLines [][]Point2LL
-			bcg := ""
-			// Prefer BCG label aligned with the filter index; treat 0 as empty
-			if j >= 0 && j < len(geoMap.BcgMenu) && int(geoMap.BcgMenu[j]) != 0 {
-				bcg = strconv.Itoa(int(geoMap.BcgMenu[j]))
-			}
+			// Aggregate lines, text and symbols across all video maps for this filter
+			var aggregatedStyledLines []ERAMLine
+			var aggregatedText []ERAMText
+			var aggregatedSymbols []ERAMSymbol
+			var sourceVideoMapIds []string
+			// Prefer BCG label aligned with the filter index
+			bcg := bcgLabel(geoMap.BcgMenu, j)
 
 			for _, videoMapID := range geoMap.VideoMapIds {
-				// log.Printf("  Processing video map %d/%d: %s", i+1, len(geoMap.VideoMapIds), videoMapID)
-				file, err := os.Open(currentDir + "/VideoMaps/" + inputARTCC + "/" + videoMapID + ".geojson")
+				vm, err := cache.Get(videoMapID)
 				if err != nil {
-					log.Fatalf("Error opening video map file %s: %v", videoMapID, err)
+					// Reported once for the geomap above; note the filter.
+					d := &diags[badVideoMaps[videoMapID]]
+					d.Filters = append(d.Filters, j+1)
+					continue
 				}
 
-				var gj GeoJSON
-				err = json.NewDecoder(file).Decode(&gj)
-				file.Close()
-				if err != nil {
-					log.Fatalf("Error decoding video map file %s: %v", videoMapID, err)
-				}
-
-				// Collect per-file defaults from special features
-				lineDefaults := GeoJSONProperties{}
-				for _, f := range gj.Features {
-					if f.Properties == nil {
-						continue
-					}
-					if f.Properties.IsLineDefaults {
-						lineDefaults = *f.Properties
-					}
-					// Note: text/symbol defaults are not needed for line extraction
+				// Filter membership: CRC filters are 1-based; adjust for zero-based j
+				if len(vm.ByFilter[j+1]) > 0 {
+					sourceVideoMapIds = append(sourceVideoMapIds, videoMapID)
 				}
+				for _, feature := range vm.ByFilter[j+1] {
+					eff := &feature.Props
+
+					// Each element keeps its own BCG since a filter can mix
+					// brightness categories; blanks are filled in below.
+					featureBcg := bcgLabel(geoMap.BcgMenu, eff.Bcg-1)
+
+					switch feature.Kind {
+					case featureLine:
+						for _, line := range feature.Geometry.Lines {
+							style, segments := feature.LineStyle, [][]Point2LL{line}
+							if opts.bakeDashes {
+								// Legacy output: split dashed lines into solid
+								// dash segments.
+								if pattern, ok := opts.dashPatterns[feature.LineStyle]; ok {
+									segments = buildDashedSegments(line, pattern)
+								}
+								style = LineStyleSolid
+							}
+							for _, seg := range segments {
+								aggregatedStyledLines = append(aggregatedStyledLines, ERAMLine{
+									Points:    seg,
+									Style:     style,
+									Thickness: eff.Thickness,
+									BcgName:   featureBcg,
+								})
+							}
+						}
 
-				// Process features with fallback to defaults
-				for _, feature := range gj.Features {
-					if feature.Type != "Feature" {
-						continue
-					}
-					// Skip defaults features themselves
-					if feature.Properties != nil && (feature.Properties.IsLineDefaults || feature.Properties.IsTextDefaults || feature.Properties.IsSymbolDefaults) {
-						continue
-					}
-
-					// Only extract lines for output
-					if feature.Geometry.Type != "LineString" {
-						// log.Printf("    Skipping non-LineString feature: %s. Current %v. Len %v", feature.Geometry.Type, k, len(gj.Features))
-						continue
-					}
-
-					// Determine effective properties by applying defaults
-					eff := GeoJSONProperties{}
-					if feature.Properties != nil {
-						eff = *feature.Properties
-					}
-					// Apply defaults where missing
-					if eff.Bcg == 0 && lineDefaults.Bcg != 0 {
-						eff.Bcg = lineDefaults.Bcg
-					}
-					if len(eff.Filters) == 0 && len(lineDefaults.Filters) != 0 {
-						eff.Filters = append([]int(nil), lineDefaults.Filters...)
-					}
-					if eff.Style == "" && lineDefaults.Style != "" {
-						eff.Style = lineDefaults.Style
-					}
-					if eff.Thickness == 0 && lineDefaults.Thickness != 0 {
-						eff.Thickness = lineDefaults.Thickness
-					}
+					case featureText:
+						for _, pt := range feature.Geometry.Points {
+							aggregatedText = append(aggregatedText, ERAMText{
+								Location:  pt,
+								Text:      strings.Join(eff.Text, "\n"),
+								Size:      eff.Size,
+								Underline: eff.Underline,
+								Opaque:    eff.Opaque,
+								XOffset:   eff.XOffset,
+								YOffset:   eff.YOffset,
+								BcgName:   featureBcg,
+							})
+						}
 
-					// Filter membership: CRC filters are 1-based; adjust for zero-based j
-					if !slices.Contains(eff.Filters, j+1) {
-						continue
+					case featureSymbol:
+						for _, pt := range feature.Geometry.Points {
+							aggregatedSymbols = append(aggregatedSymbols, ERAMSymbol{
+								Style:    feature.SymbolStyle,
+								Size:     eff.Size,
+								Location: pt,
+								BcgName:  featureBcg,
+							})
+						}
 					}
 
-					// Append line(s), splitting into dash segments when style indicates dashed
-					switch normalizeStyle(eff.Style) {
-					case "shortdashed", "shortdash", "dashed":
-						segments := buildDashedSegments(feature.Geometry.Coordinates, 1.0/60.0, 1.0/60.0)
-						aggregatedLines = append(aggregatedLines, segments...)
-					case "longdashed", "longdash":
-						segments := buildDashedSegments(feature.Geometry.Coordinates, 2.0/60.0, 2.0/60.0)
-						aggregatedLines = append(aggregatedLines, segments...)
-					default:
-						aggregatedLines = append(aggregatedLines, feature.Geometry.Coordinates)
+					// Only use element BCG if no filter-index BCG was set
+					if bcg == "" {
+						bcg = featureBcg
 					}
+				}
+			}
 
-					if eff.Bcg-1 >= 0 && eff.Bcg-1 < len(geoMap.BcgMenu) {
-						// Only use element BCG if no filter-index BCG was set
-						if bcg == "" {
-							bcg = strconv.Itoa(int(geoMap.BcgMenu[eff.Bcg-1]))
-						}
-					}
+			// Elements without a BCG of their own use the map's
+			for i := range aggregatedStyledLines {
+				if aggregatedStyledLines[i].BcgName == "" {
+					aggregatedStyledLines[i].BcgName = bcg
+				}
+			}
+			for i := range aggregatedText {
+				if aggregatedText[i].BcgName == "" {
+					aggregatedText[i].BcgName = bcg
+				}
+			}
+			for i := range aggregatedSymbols {
+				if aggregatedSymbols[i].BcgName == "" {
+					aggregatedSymbols[i].BcgName = bcg
 				}
 			}
 
-			// Only append a map entry if we found any lines for this filter
-			if len(aggregatedLines) > 0 {
+			// Only append a map entry if we found anything for this filter
+			if len(aggregatedStyledLines) > 0 || len(aggregatedText) > 0 || len(aggregatedSymbols) > 0 {
 				group.Maps = append(group.Maps, ERAMMap{
-					BcgName:    bcg,
-					LabelLine1: filterMenu.LabelLine1,
-					LabelLine2: filterMenu.LabelLine2,
-					Name:       geoMap.Name,
-					Lines:      aggregatedLines,
+					BcgName:     bcg,
+					LabelLine1:  filterMenu.LabelLine1,
+					LabelLine2:  filterMenu.LabelLine2,
+					Name:        geoMap.Name,
+					Filter:      j + 1,
+					VideoMapIds: sourceVideoMapIds,
+					StyledLines: aggregatedStyledLines,
+					Text:        aggregatedText,
+					Symbols:     aggregatedSymbols,
 				})
+			} else if opts.keepBlankFilters {
+				group.Maps = append(group.Maps, placeholder)
 			}
 
 		}
+		group.Name = geoMap.Name
 		group.LabelLine1 = geoMap.LabelLine1
 		group.LabelLine2 = geoMap.LabelLine2
-		output[geoMap.Name] = group
+		for i := range geoMap.BcgMenu {
+			group.BcgMenu = append(group.BcgMenu, bcgLabel(geoMap.BcgMenu, i))
+		}
+		for j, filterMenu := range geoMap.FilterMenu {
+			group.FilterMenu = append(group.FilterMenu, ERAMFilterMenuItem{
+				ID:         filterMenu.ID,
+				Filter:     j + 1,
+				LabelLine1: filterMenu.LabelLine1,
+				LabelLine2: filterMenu.LabelLine2,
+			})
+		}
+		output = append(output, group)
+	}
+
+	// Count each feature once, however many geomaps and filters use it.
+	geometryCounts := make(map[string]int) // GeoJSON geometry type -> features
+	for _, vm := range cache.Loaded() {
+		for _, f := range vm.All {
+			geometryCounts[f.Geometry.Type]++
+		}
+		if len(vm.UnknownLineStyles) > 0 {
+			lg.Printf("Warning: video map %s has unrecognized line styles %q; drawn as solid", vm.ID, vm.UnknownLineStyles)
+		}
+		if len(vm.UnknownSymbolStyles) > 0 {
+			lg.Printf("Warning: video map %s has unrecognized symbol styles %q; skipped", vm.ID, vm.UnknownSymbolStyles)
+		}
 	}
 
 	// Write the output to a file
-	log.Println("Preparing to write output file...")
-	log.Printf("Output contains %d geomap groups", len(output))
+	lg.Println("Preparing to write output file...")
+	lg.Printf("Output contains %d geomap groups", len(output))
 
 	// Calculate some statistics
 	totalMaps := 0
 	totalLines := 0
-	for groupName, group := range output {
-		log.Printf("  %s: %d maps", groupName, len(group.Maps))
+	totalText := 0
+	totalSymbols := 0
+	for _, group := range output {
+		lg.Printf("  %s: %d maps", group.Name, len(group.Maps))
 		totalMaps += len(group.Maps)
 		for _, mapItem := range group.Maps {
-			totalLines += len(mapItem.Lines)
+			totalLines += len(mapItem.StyledLines)
+			totalText += len(mapItem.Text)
+			totalSymbols += len(mapItem.Symbols)
 		}
 	}
-	log.Printf("Total maps processed: %d", totalMaps)
-	log.Printf("Total LineString features extracted: %d", totalLines)
+	lg.Printf("Total maps processed: %d", totalMaps)
+	lg.Printf("Total lines extracted: %d", totalLines)
+	lg.Printf("Total Text features extracted: %d", totalText)
+	lg.Printf("Total Symbol features extracted: %d", totalSymbols)
+	for _, geomType := range slices.Sorted(maps.Keys(geometryCounts)) {
+		lg.Printf("  %s features extracted: %d", geomType, geometryCounts[geomType])
+	}
 
-	outputFile, err := os.Create(inputARTCC + "-eram-videomaps.json")
+	summary.Maps = totalMaps
+	summary.Lines = totalLines
+	summary.Text = totalText
+	summary.Symbols = totalSymbols
+	summary.Diagnostics = len(diags)
+
+	outputFile, err := os.Create(filepath.Join(opts.outDir, artccID+"-eram-videomaps.json"))
 	if err != nil {
-		log.Fatalf("Error creating output file: %v", err)
+		return summary, fmt.Errorf("creating output file: %w", err)
 	}
 	defer outputFile.Close()
 
-	log.Println("Writing output to JSON file...")
+	lg.Println("Writing output to JSON file...")
 	err = json.NewEncoder(outputFile).Encode(output)
 	if err != nil {
-		log.Fatalf("Error writing output file: %v", err)
+		return summary, fmt.Errorf("writing output file: %w", err)
 	}
 
-	log.Printf("✓ Output successfully written to %s", outputFile.Name())
+	lg.Printf("✓ Output successfully written to %s", outputFile.Name())
 
-	// Also write gob+zstd file of the JSON output
-	fn := inputARTCC + "-eram-videomaps.gob"
-	log.Printf("Writing compressed output to %s...", fn)
-	f, err := os.Create(fn)
-	if err != nil {
-		log.Fatalf("Error creating file: %v", err)
+	// Also write gob file of the JSON output, zstd-compressed by default
+	fn := gobFilename(filepath.Join(opts.outDir, artccID+"-eram-videomaps"), !opts.rawGob)
+	lg.Printf("Writing gob output to %s...", fn)
+	if err := writeGob(fn, output, !opts.rawGob); err != nil {
+		return summary, fmt.Errorf("writing gob payload: %w", err)
 	}
-	defer f.Close()
 
-	ge := gob.NewEncoder(f)
-	if err := ge.Encode(output); err != nil {
-		log.Fatalf("Error writing gob payload: %v", err)
+	base := filepath.Join(opts.outDir, artccID+"-eram-manifest")
+	lg.Printf("Writing manifest to %s...", gobFilename(base, !opts.rawGob))
+	if err := writeManifest(base, buildManifest(output), !opts.rawGob); err != nil {
+		return summary, fmt.Errorf("writing manifest: %w", err)
 	}
 
-	fn = strings.Replace(fn, "videomaps", "manifest", 1)
-	log.Printf("Writing manifest to %s...", fn)
-
-	f, err = os.Create(fn)
-	if err != nil {
-		log.Fatalf("Error creating file: %v", err)
+	ctx := &exportContext{artcc: artcc, cache: cache, opts: opts, lg: lg}
+	if err := runExports(artccID, ctx); err != nil {
+		return summary, err
 	}
-	defer f.Close()
-
-	combine := func(x, y string) string {
-		x = strings.TrimSpace(x)
-		y = strings.TrimSpace(y)
 
-		if x == "" {
-			return y
-		}
-		if y == "" {
-			return x
-		}
-
-		// add space unless x already ends with space OR y already starts with space
-		if strings.HasSuffix(x, " ") || strings.HasPrefix(y, " ") {
-			return x + y
-		}
-		return x + " " + y
+	fn = filepath.Join(opts.outDir, artccID+"-eram-diagnostics.json")
+	lg.Printf("Writing %d diagnostics to %s...", len(diags), fn)
+	if err := writeDiagnostics(fn, diags); err != nil {
+		return summary, fmt.Errorf("writing diagnostics: %w", err)
 	}
-
-	// Create output with only map names
-	manifest := make(map[string]interface{}) // MapGroup -> []MapNames
-	for groupName, group := range output {
-		for _, mapItem := range group.Maps {
-			if _, ok := manifest[groupName]; !ok {
-				manifest[groupName] = []string{}
-			}
-			manifest[groupName] = append(manifest[groupName].([]string), combine(mapItem.LabelLine1, mapItem.LabelLine2))
+	if opts.strict {
+		errs := ctx.strictErrs
+		if len(diags) > 0 {
+			errs = append([]error{fmt.Errorf("%d video map file(s) could not be used (see %s)", diagnosedFiles(diags), fn)}, errs...)
+		}
+		if err := errors.Join(errs...); err != nil {
+			return summary, err
 		}
 	}
 
-	fn = strings.Replace(fn, "gob", "json", 1)
-	jf, err := os.Create(fn)
-	if err != nil {
-		log.Fatalf("Error creating file: %v", err)
-	}
-	defer jf.Close()
-
-	je := json.NewEncoder(jf)
-	if err := je.Encode(manifest); err != nil {
-		log.Fatalf("Error writing json payload: %v", err)
-	}
+	return summary, nil
+}
 
-	ge = gob.NewEncoder(f)
-	if err := ge.Encode(manifest); err != nil {
-		log.Fatalf("Error writing gob payload: %v", err)
+// bcgLabel returns the label of the i'th (0-based) entry of a geomap's
+// BCG menu. Entries of 0 and indices outside the menu have no label.
+func bcgLabel(menu []StringOrInt, i int) string {
+	if i < 0 || i >= len(menu) || menu[i] == 0 {
+		return ""
 	}
-
-	log.Println("=== CRC ERAM Map Processor Complete ===")
+	return strconv.Itoa(int(menu[i]))
 }
 
+// normalizeStyle lowercases a CRC style name and strips spaces and
+// underscores so that variant spellings compare equal.
 func normalizeStyle(s string) string {
 	s = strings.ToLower(strings.TrimSpace(s))
 	s = strings.ReplaceAll(s, " ", "")
 	s = strings.ReplaceAll(s, "_", "")
 	return s
 }
-
-func buildDashedSegments(coords []Point2LL, dashLenDeg float64, gapLenDeg float64) [][]Point2LL {
-	if len(coords) < 2 || dashLenDeg <= 0 || gapLenDeg < 0 {
-		return [][]Point2LL{coords}
-	}
-	var segments [][]Point2LL
-	// State
-	onDash := true
-	remaining := dashLenDeg
-	if !onDash {
-		remaining = gapLenDeg
-	}
-	// Current segment points when in dash phase
-	var cur []Point2LL
-
-	// Helper to emit and reset the current dash segment
-	emit := func() {
-		if len(cur) >= 2 {
-			// Copy to avoid aliasing
-			seg := make([]Point2LL, len(cur))
-			copy(seg, cur)
-			segments = append(segments, seg)
-		}
-		cur = cur[:0]
-	}
-
-	// Iterate over each segment of the input polyline
-	for i := 0; i < len(coords)-1; i++ {
-		x1 := float64(coords[i][1])
-		y1 := float64(coords[i][0])
-		x2 := float64(coords[i+1][1])
-		y2 := float64(coords[i+1][0])
-		dx := x2 - x1
-		dy := y2 - y1
-		segLen := math.Hypot(dx, dy)
-		if segLen == 0 {
-			continue
-		}
-		// Unit direction
-		ux := dx / segLen
-		uy := dy / segLen
-
-		// Current position along this segment
-		cx := x1
-		cy := y1
-
-		// For dash segments, start with the starting point
-		if onDash && len(cur) == 0 {
-			cur = append(cur, Point2LL{float32(y1), float32(x1)})
-		}
-
-		remainingInThis := remaining
-		traveled := 0.0
-		for traveled < segLen {
-			step := math.Min(remainingInThis, segLen-traveled)
-			// Advance by step
-			cx += ux * step
-			cy += uy * step
-			traveled += step
-
-			if onDash {
-				// Record the point in the dash
-				cur = append(cur, Point2LL{float32(cy), float32(cx)})
-			}
-
-			remainingInThis -= step
-			if remainingInThis <= 1e-9 {
-				// Toggle phase and reset remaining for next phase
-				onDash = !onDash
-				if onDash {
-					remainingInThis = dashLenDeg
-					// Start a new dash from current point
-					cur = append(cur, Point2LL{float32(cy), float32(cx)})
-				} else {
-					// Emit completed dash
-					emit()
-					remainingInThis = gapLenDeg
-				}
-			}
-		}
-
-		// Carry remaining into next input segment
-		remaining = remainingInThis
-		if onDash && len(cur) == 0 {
-			// Ensure continuity of dash across vertices
-			cur = append(cur, Point2LL{float32(cy), float32(cx)})
-		}
-	}
-
-	// If we ended while on a dash, emit it
-	if onDash {
-		emit()
-	}
-	if len(segments) == 0 {
-		return [][]Point2LL{coords}
-	}
-	return segments
-}
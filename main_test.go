@@ -0,0 +1,21 @@
+package main
+
+import "testing"
+
+func TestBcgLabel(t *testing.T) {
+	menu := []StringOrInt{1, 0, 12}
+	for _, tc := range []struct {
+		i        int
+		expected string
+	}{
+		{-1, ""},
+		{0, "1"},
+		{1, ""}, // 0 entries inherit the map's BCG
+		{2, "12"},
+		{3, ""},
+	} {
+		if got := bcgLabel(menu, tc.i); got != tc.expected {
+			t.Errorf("bcgLabel(%v, %d) = %q, expected %q", menu, tc.i, got, tc.expected)
+		}
+	}
+}
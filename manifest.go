@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ERAMManifest describes the map menus of an ARTCC without any of the
+// geometry: geomap groups are in the order of the ERAM configuration and
+// their maps in filter order.
+type ERAMManifest struct {
+	Groups []ERAMManifestGroup
+}
+
+type ERAMManifestGroup struct {
+	Name       string
+	LabelLine1 string
+	LabelLine2 string
+	Maps       []ERAMManifestMap
+}
+
+type ERAMManifestMap struct {
+	Name        string // LabelLine1 and LabelLine2 combined
+	Filter      int    // 1-based CRC filter index
+	BcgName     string
+	LabelLine1  string
+	LabelLine2  string
+	VideoMapIds []string // Source video maps that contributed to the map
+	Placeholder bool     // Blank or empty filter slot
+}
+
+func buildManifest(output ERAMMapGroups) ERAMManifest {
+	manifest := ERAMManifest{Groups: []ERAMManifestGroup{}}
+	for _, group := range output {
+		mg := ERAMManifestGroup{
+			Name:       group.Name,
+			LabelLine1: group.LabelLine1,
+			LabelLine2: group.LabelLine2,
+			Maps:       []ERAMManifestMap{},
+		}
+		for _, mapItem := range group.Maps {
+			mg.Maps = append(mg.Maps, ERAMManifestMap{
+				Name:        combineLabels(mapItem.LabelLine1, mapItem.LabelLine2),
+				Filter:      mapItem.Filter,
+				BcgName:     mapItem.BcgName,
+				LabelLine1:  mapItem.LabelLine1,
+				LabelLine2:  mapItem.LabelLine2,
+				VideoMapIds: append([]string{}, mapItem.VideoMapIds...),
+				Placeholder: mapItem.Placeholder,
+			})
+		}
+		manifest.Groups = append(manifest.Groups, mg)
+	}
+	return manifest
+}
+
+// normalizeManifest replaces nil slices with empty ones, since gob
+// decodes empty slices as nil.
+func normalizeManifest(m ERAMManifest) ERAMManifest {
+	if m.Groups == nil {
+		m.Groups = []ERAMManifestGroup{}
+	}
+	for i := range m.Groups {
+		if m.Groups[i].Maps == nil {
+			m.Groups[i].Maps = []ERAMManifestMap{}
+		}
+		for j := range m.Groups[i].Maps {
+			if m.Groups[i].Maps[j].VideoMapIds == nil {
+				m.Groups[i].Maps[j].VideoMapIds = []string{}
+			}
+		}
+	}
+	return m
+}
+
+// combineLabels joins two menu label lines into a single name.
+func combineLabels(x, y string) string {
+	x = strings.TrimSpace(x)
+	y = strings.TrimSpace(y)
+
+	if x == "" {
+		return y
+	}
+	if y == "" {
+		return x
+	}
+
+	// add space unless x already ends with space OR y already starts with space
+	if strings.HasSuffix(x, " ") || strings.HasPrefix(y, " ") {
+		return x + y
+	}
+	return x + " " + y
+}
+
+// writeManifest writes the manifest as both JSON and gob, using base for
+// the filenames. The gob is read back after it is written to verify that
+// it decodes to the same manifest.
+func writeManifest(base string, manifest ERAMManifest, compress bool) error {
+	if err := writeJSONAndGob(base, manifest, compress); err != nil {
+		return err
+	}
+
+	fn := gobFilename(base, compress)
+	var check ERAMManifest
+	if err := readGob(fn, &check); err != nil {
+		return fmt.Errorf("%s: reading back gob: %w", fn, err)
+	}
+	if !reflect.DeepEqual(normalizeManifest(check), normalizeManifest(manifest)) {
+		return fmt.Errorf("%s: gob doesn't decode to the manifest that was written", fn)
+	}
+	return nil
+}
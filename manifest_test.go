@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func testManifestOutput() ERAMMapGroups {
+	return ERAMMapGroups{
+		{
+			Name:       "CENTER",
+			LabelLine1: "CTR",
+			LabelLine2: "MAP",
+			Maps: []ERAMMap{
+				{Name: "CENTER", LabelLine1: "HI", LabelLine2: "AWY", Filter: 1, BcgName: "1", VideoMapIds: []string{"vm1", "vm2"}},
+				{Name: "CENTER", Filter: 2, Placeholder: true},
+				{Name: "CENTER", LabelLine1: "FIX", LabelLine2: "NAMES", Filter: 3, BcgName: "3", VideoMapIds: []string{"vm1"}},
+			},
+		},
+		// A geomap without any maps, e.g. one whose filters are all blank.
+		{Name: "EMPTY", LabelLine1: "NO", LabelLine2: "MAPS"},
+	}
+}
+
+func TestManifestRoundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		output   ERAMMapGroups
+		compress bool
+	}{
+		{"zstd", testManifestOutput(), true},
+		{"raw", testManifestOutput(), false},
+		{"no geomaps", ERAMMapGroups{}, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			manifest := buildManifest(tc.output)
+			base := filepath.Join(t.TempDir(), "ZXX-eram-manifest")
+			if err := writeManifest(base, manifest, tc.compress); err != nil {
+				t.Fatalf("writeManifest: %v", err)
+			}
+			expected := normalizeManifest(manifest)
+
+			var fromGob ERAMManifest
+			if err := readGob(gobFilename(base, tc.compress), &fromGob); err != nil {
+				t.Fatalf("readGob: %v", err)
+			}
+			if got := normalizeManifest(fromGob); !reflect.DeepEqual(got, expected) {
+				t.Errorf("gob round trip:\ngot      %+v\nexpected %+v", got, expected)
+			}
+
+			b, err := os.ReadFile(base + ".json")
+			if err != nil {
+				t.Fatal(err)
+			}
+			var fromJSON ERAMManifest
+			if err := json.Unmarshal(b, &fromJSON); err != nil {
+				t.Fatalf("decoding JSON: %v", err)
+			}
+			if got := normalizeManifest(fromJSON); !reflect.DeepEqual(got, expected) {
+				t.Errorf("JSON round trip:\ngot      %+v\nexpected %+v", got, expected)
+			}
+		})
+	}
+}
+
+func TestBuildManifest(t *testing.T) {
+	m := buildManifest(testManifestOutput())
+	if len(m.Groups) != 2 {
+		t.Fatalf("got %d groups, expected 2", len(m.Groups))
+	}
+	maps := m.Groups[0].Maps
+	if len(maps) != 3 {
+		t.Fatalf("got %d maps, expected 3", len(maps))
+	}
+	if maps[0].Name != "HI AWY" || maps[2].Name != "FIX NAMES" {
+		t.Errorf("got names %q and %q, expected combined label lines", maps[0].Name, maps[2].Name)
+	}
+	if !maps[1].Placeholder || maps[1].Filter != 2 {
+		t.Errorf("expected filter 2 to be a placeholder, got %+v", maps[1])
+	}
+	if len(m.Groups[1].Maps) != 0 {
+		t.Errorf("expected no maps in the empty group, got %d", len(m.Groups[1].Maps))
+	}
+}
+
+func TestBuildManifestNonNil(t *testing.T) {
+	m := buildManifest(testManifestOutput())
+	if !reflect.DeepEqual(m, normalizeManifest(m)) {
+		t.Errorf("buildManifest returned nil slices: %+v", m)
+	}
+}
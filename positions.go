@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"encoding/gob"
+	"maps"
+	"math"
+	"slices"
+)
+
+// ERAMPositions lists an ARTCC's ERAM sectors in the form of vice's
+// control position configuration, keyed by callsign.
+type ERAMPositions struct {
+	Facility  string          `json:"facility"`
+	Positions ERAMPositionMap `json:"control_positions"`
+}
+
+// ERAMPositionMap holds positions keyed by callsign. gob writes maps in
+// random order, so it is encoded as a list sorted by callsign to keep the
+// output reproducible.
+type ERAMPositionMap map[string]ERAMPosition
+
+func (m ERAMPositionMap) GobEncode() ([]byte, error) {
+	var positions []ERAMPosition
+	for _, callsign := range slices.Sorted(maps.Keys(m)) {
+		positions = append(positions, m[callsign])
+	}
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(positions)
+	return buf.Bytes(), err
+}
+
+func (m *ERAMPositionMap) GobDecode(b []byte) error {
+	var positions []ERAMPosition
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&positions); err != nil {
+		return err
+	}
+	*m = make(ERAMPositionMap)
+	for _, p := range positions {
+		(*m)[p.Callsign] = p
+	}
+	return nil
+}
+
+type ERAMPosition struct {
+	ID           string            `json:"id"`
+	Name         string            `json:"full_name"`
+	Callsign     string            `json:"callsign"`
+	RadioName    string            `json:"radio_name"`
+	Frequency    int               `json:"frequency"` // kHz
+	SectorID     string            `json:"sector_id"`
+	Facility     string            `json:"facility"`
+	ERAMFacility bool              `json:"eram_facility"`
+	Transceivers []ERAMTransceiver `json:"transceivers,omitempty"`
+}
+
+type ERAMTransceiver struct {
+	ID              string   `json:"id"`
+	Name            string   `json:"name"`
+	Location        Point2LL `json:"location"` // [lon, lat]
+	HeightMslMeters float32  `json:"height_msl_meters"`
+	HeightAglMeters float32  `json:"height_agl_meters"`
+}
+
+// frequencyKHz converts a CRC frequency, which is given in Hz, to the
+// kHz that vice uses. Values that already look like MHz are handled as
+// well.
+func frequencyKHz(f float32) int {
+	if f < 1000 {
+		return int(math.Round(float64(f) * 1000))
+	}
+	return int(math.Round(float64(f) / 1000))
+}
+
+func exportPositions(ctx *exportContext) (any, error) {
+	artcc := ctx.artcc
+
+	transceivers := make(map[string]ERAMTransceiver)
+	for _, t := range artcc.Transceivers {
+		transceivers[t.ID] = ERAMTransceiver{
+			ID:              t.ID,
+			Name:            t.Name,
+			Location:        Point2LL{float32(t.Location.Lon), float32(t.Location.Lat)},
+			HeightMslMeters: t.HeightMslMeters,
+			HeightAglMeters: t.HeightAglMeters,
+		}
+	}
+
+	out := ERAMPositions{Facility: artcc.Facility.ID, Positions: make(ERAMPositionMap)}
+	for _, p := range artcc.Facility.Positions {
+		pos := ERAMPosition{
+			ID:           p.ID,
+			Name:         p.Name,
+			Callsign:     p.Callsign,
+			RadioName:    p.RadioName,
+			Frequency:    frequencyKHz(p.Frequency),
+			SectorID:     p.EramConfiguration.SectorID,
+			Facility:     artcc.Facility.ID,
+			ERAMFacility: true,
+		}
+		for _, id := range p.TransceiverIds {
+			if t, ok := transceivers[id]; ok {
+				pos.Transceivers = append(pos.Transceivers, t)
+			} else {
+				ctx.lg.Printf("Warning: position %s references unknown transceiver %s", p.Callsign, id)
+			}
+		}
+		if _, ok := out.Positions[p.Callsign]; ok {
+			ctx.lg.Printf("Warning: skipping position %s: callsign %s is used by another position", p.ID, p.Callsign)
+			continue
+		}
+		out.Positions[p.Callsign] = pos
+	}
+
+	ctx.lg.Printf("Exported %d ERAM positions", len(out.Positions))
+	return out, nil
+}
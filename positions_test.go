@@ -0,0 +1,30 @@
+package main
+
+import (
+	"bytes"
+	"encoding/gob"
+	"maps"
+	"testing"
+)
+
+func TestERAMPositionMapGob(t *testing.T) {
+	for _, m := range []ERAMPositionMap{
+		{},
+		{
+			"ZXX_20_CTR": {ID: "p2", Callsign: "ZXX_20_CTR", Frequency: 125325},
+			"ZXX_10_CTR": {ID: "p1", Callsign: "ZXX_10_CTR", Frequency: 132450},
+		},
+	} {
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(ERAMPositions{Facility: "ZXX", Positions: m}); err != nil {
+			t.Fatalf("encoding: %v", err)
+		}
+		var got ERAMPositions
+		if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+			t.Fatalf("decoding: %v", err)
+		}
+		if !maps.EqualFunc(got.Positions, m, func(a, b ERAMPosition) bool { return a.ID == b.ID && a.Frequency == b.Frequency }) {
+			t.Errorf("got %v, expected %v", got.Positions, m)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"slices"
+)
+
+// STARSVideoMaps holds a TRACON's video maps in the form of vice's STARS
+// video maps, in the order of the facility's STARS configuration.
+type STARSVideoMaps struct {
+	Facility  string
+	Maps      []STARSMap
+	MapGroups []STARSMapGroup
+}
+
+type STARSMap struct {
+	Id            int    // STARS map number
+	Label         string // Short name shown in the DCB
+	Name          string
+	Group         int // Brightness category: 0 is A, 1 is B
+	AlwaysVisible bool
+	VideoMapId    string
+	// MapGroups lists the IDs of the map groups the map belongs to.
+	MapGroups []string
+	Lines     [][]Point2LL
+}
+
+// STARSMapGroup is an ordered group of map numbers, as shown on the DCB
+// maps menu; blank slots are 0.
+type STARSMapGroup struct {
+	ID   string
+	Maps []int
+	Tcps []string
+}
+
+// STARSManifest describes a TRACON's STARS maps and map groups without
+// any of the geometry, as ERAMManifest does for an ARTCC's ERAM maps.
+type STARSManifest struct {
+	Facility  string
+	Maps      []STARSManifestMap
+	MapGroups []STARSMapGroup
+}
+
+type STARSManifestMap struct {
+	Id            int
+	Label         string
+	Name          string
+	Group         int
+	AlwaysVisible bool
+	VideoMapId    string
+	MapGroups     []string
+}
+
+func buildSTARSManifest(output STARSVideoMaps) STARSManifest {
+	manifest := STARSManifest{Facility: output.Facility, Maps: []STARSManifestMap{}, MapGroups: output.MapGroups}
+	for _, sm := range output.Maps {
+		manifest.Maps = append(manifest.Maps, STARSManifestMap{
+			Id:            sm.Id,
+			Label:         sm.Label,
+			Name:          sm.Name,
+			Group:         sm.Group,
+			AlwaysVisible: sm.AlwaysVisible,
+			VideoMapId:    sm.VideoMapId,
+			MapGroups:     sm.MapGroups,
+		})
+	}
+	return manifest
+}
+
+// findTRACON returns the ARTCC whose child facilities include
+// the given TRACON. If artccID is given only that ARTCC is checked;
+// otherwise all ARTCCs in the CRC directory are searched.
+func findTRACON(crcDir, artccID, tracon string) (*ARTCC, error) {
+	ids := []string{artccID}
+	if artccID == "" {
+		var err error
+		if ids, err = discoverARTCCs(crcDir); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, id := range ids {
+		artcc, err := loadARTCC(crcDir, id)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", id, err)
+		}
+		for _, child := range artcc.Facility.ChildFacilities {
+			if child.ID == tracon {
+				return artcc, nil
+			}
+		}
+	}
+	if artccID != "" {
+		return nil, fmt.Errorf("%s: not a child facility of %s", tracon, artccID)
+	}
+	return nil, fmt.Errorf("%s: not found in any ARTCC", tracon)
+}
+
+// processSTARS converts the STARS video maps of a TRACON and writes them
+// to the output directory. Missing or undecodable video map files are
+// reported as diagnostics as with ERAM maps.
+func processSTARS(crcDir, artccID, tracon string, opts options, lg *log.Logger) error {
+	lg.Printf("Processing STARS facility: %s", tracon)
+
+	artcc, err := findTRACON(crcDir, artccID, tracon)
+	if err != nil {
+		return err
+	}
+	lg.Printf("Found %s in ARTCC %s", tracon, artcc.ID)
+
+	videoMapsDir := filepath.Join(crcDir, "VideoMaps", artcc.ID)
+	if err := checkDir(videoMapsDir); err != nil {
+		return fmt.Errorf("video maps for %s: %w", artcc.ID, err)
+	}
+	cache := newVideoMapCache(videoMapsDir)
+
+	var sc *StarsConfiguration
+	for i := range artcc.Facility.ChildFacilities {
+		if artcc.Facility.ChildFacilities[i].ID == tracon {
+			sc = &artcc.Facility.ChildFacilities[i].StarsConfiguration
+			break
+		}
+	}
+
+	output := STARSVideoMaps{Facility: tracon}
+
+	// Map groups refer to maps by STARS map number; null entries are
+	// blank slots.
+	groupsByMap := make(map[int][]string)
+	for _, g := range sc.MapGroups {
+		group := STARSMapGroup{ID: g.ID, Tcps: g.Tcps}
+		for _, m := range g.MapIds {
+			n := 0
+			if f, ok := m.(float64); ok {
+				n = int(f)
+			}
+			group.Maps = append(group.Maps, n)
+			if n != 0 {
+				groupsByMap[n] = append(groupsByMap[n], g.ID)
+			}
+		}
+		output.MapGroups = append(output.MapGroups, group)
+	}
+
+	var diags []Diagnostic
+	for _, videoMapID := range sc.VideoMapIds {
+		i := slices.IndexFunc(artcc.VideoMaps, func(vm VideoMapInfo) bool { return vm.ID == videoMapID })
+		if i == -1 {
+			lg.Printf("  Warning: skipping video map %s: not in ARTCC video map list", videoMapID)
+			diags = append(diags, Diagnostic{VideoMapID: videoMapID, Error: "not in ARTCC video map list"})
+			continue
+		}
+		info := artcc.VideoMaps[i]
+
+		vm, err := cache.Get(videoMapID)
+		if err != nil {
+			lg.Printf("  Warning: skipping video map %s: %v", videoMapID, err)
+			diags = append(diags, Diagnostic{VideoMapID: videoMapID, Error: err.Error()})
+			continue
+		}
+
+		sm := STARSMap{
+			Id:            int(info.StarsID),
+			Label:         info.ShortName,
+			Name:          info.Name,
+			AlwaysVisible: info.StarsAlwaysVisible,
+			VideoMapId:    videoMapID,
+			MapGroups:     groupsByMap[int(info.StarsID)],
+		}
+		if sm.Label == "" {
+			sm.Label = info.Name
+		}
+		if info.StarsBrightnessCategory == "B" {
+			sm.Group = 1
+		}
+		// STARS maps are drawn as plain lines; text and symbols aren't used.
+		for _, f := range vm.All {
+			if f.Kind == featureLine {
+				sm.Lines = append(sm.Lines, f.Geometry.Lines...)
+			}
+		}
+
+		lg.Printf("  Map %d %s (%s): %d lines", sm.Id, sm.Label, info.StarsBrightnessCategory, len(sm.Lines))
+		output.Maps = append(output.Maps, sm)
+	}
+
+	base := filepath.Join(opts.outDir, tracon+"-stars-videomaps")
+	lg.Printf("Writing %d STARS maps to %s...", len(output.Maps), gobFilename(base, !opts.rawGob))
+	if err := writeJSONAndGob(base, output, !opts.rawGob); err != nil {
+		return err
+	}
+
+	base = filepath.Join(opts.outDir, tracon+"-stars-manifest")
+	lg.Printf("Writing manifest to %s...", gobFilename(base, !opts.rawGob))
+	if err := writeJSONAndGob(base, buildSTARSManifest(output), !opts.rawGob); err != nil {
+		return fmt.Errorf("writing manifest: %w", err)
+	}
+
+	fn := filepath.Join(opts.outDir, tracon+"-stars-diagnostics.json")
+	if err := writeDiagnostics(fn, diags); err != nil {
+		return fmt.Errorf("writing diagnostics: %w", err)
+	}
+	if opts.strict && len(diags) > 0 {
+		return fmt.Errorf("%d video map file(s) could not be used (see %s)", diagnosedFiles(diags), fn)
+	}
+	return nil
+}
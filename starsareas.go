@@ -0,0 +1,108 @@
+package main
+
+import "slices"
+
+// STARSAreasExport holds the STARS area definitions of each of an
+// ARTCC's child facilities along with the ARTCC's ASR sites, as a vice
+// scenario fragment.
+type STARSAreasExport struct {
+	Facilities []STARSFacilityAreas `json:"facilities"`
+	RadarSites []RadarSite          `json:"radar_sites"`
+}
+
+type STARSFacilityAreas struct {
+	ID               string      `json:"id"`
+	Name             string      `json:"name"`
+	InternalAirports []string    `json:"internal_airports,omitempty"`
+	Areas            []STARSArea `json:"areas"`
+	// RadarSites lists the IDs of the ASR sites within the surveillance
+	// range of any of the facility's areas.
+	RadarSites []string `json:"radar_sites,omitempty"`
+}
+
+type STARSArea struct {
+	ID                               string           `json:"id"`
+	Name                             string           `json:"name"`
+	Center                           Point2LL         `json:"center"` // [lon, lat]
+	Range                            float32          `json:"range"`  // nm
+	UnderlyingAirports               []string         `json:"underlying_airports,omitempty"`
+	SSAAirports                      []string         `json:"ssa_airports,omitempty"`
+	TowerLists                       []STARSTowerList `json:"tower_lists,omitempty"`
+	LDBBeaconCodesInhibited          bool             `json:"ldb_beacon_codes_inhibited,omitempty"`
+	PDBGroundSpeedInhibited          bool             `json:"pdb_ground_speed_inhibited,omitempty"`
+	DisplayRequestedAltInFDB         bool             `json:"display_requested_alt_in_fdb,omitempty"`
+	UseVFRPositionSymbol             bool             `json:"use_vfr_position_symbol,omitempty"`
+	ShowDestinationDepartures        bool             `json:"show_destination_departures,omitempty"`
+	ShowDestinationSatelliteArrivals bool             `json:"show_destination_satellite_arrivals,omitempty"`
+	ShowDestinationPrimaryArrivals   bool             `json:"show_destination_primary_arrivals,omitempty"`
+}
+
+type STARSTowerList struct {
+	Airport string  `json:"airport"`
+	Range   float32 `json:"range"` // nm
+}
+
+type RadarSite struct {
+	ID       string   `json:"id"`
+	Position Point2LL `json:"position"` // [lon, lat]
+	Range    float32  `json:"range"`    // nm
+	Ceiling  float32  `json:"ceiling"`
+}
+
+func exportSTARSAreas(ctx *exportContext) (any, error) {
+	artcc := ctx.artcc
+
+	out := STARSAreasExport{Facilities: []STARSFacilityAreas{}, RadarSites: []RadarSite{}}
+	for _, site := range artcc.Facility.EramConfiguration.AsrSites {
+		out.RadarSites = append(out.RadarSites, RadarSite{
+			ID:       site.AsrID,
+			Position: Point2LL{float32(site.Location.Lon), float32(site.Location.Lat)},
+			Range:    site.Range,
+			Ceiling:  site.Ceiling,
+		})
+	}
+
+	for _, child := range artcc.Facility.ChildFacilities {
+		sc := &child.StarsConfiguration
+		if len(sc.Areas) == 0 {
+			continue
+		}
+
+		fac := STARSFacilityAreas{
+			ID:               child.ID,
+			Name:             child.Name,
+			InternalAirports: sc.InternalAirports,
+		}
+		for _, a := range sc.Areas {
+			area := STARSArea{
+				ID:                               a.ID,
+				Name:                             a.Name,
+				Center:                           Point2LL{float32(a.VisibilityCenter.Lon), float32(a.VisibilityCenter.Lat)},
+				Range:                            a.SurveillanceRange,
+				UnderlyingAirports:               a.UnderlyingAirports,
+				SSAAirports:                      a.SsaAirports,
+				LDBBeaconCodesInhibited:          a.LdbBeaconCodesInhibited,
+				PDBGroundSpeedInhibited:          a.PdbGroundSpeedInhibited,
+				DisplayRequestedAltInFDB:         a.DisplayRequestedAltInFdb,
+				UseVFRPositionSymbol:             a.UseVfrPositionSymbol,
+				ShowDestinationDepartures:        a.ShowDestinationDepartures,
+				ShowDestinationSatelliteArrivals: a.ShowDestinationSatelliteArrivals,
+				ShowDestinationPrimaryArrivals:   a.ShowDestinationPrimaryArrivals,
+			}
+			for _, tl := range a.TowerListConfigurations {
+				area.TowerLists = append(area.TowerLists, STARSTowerList{Airport: tl.AirportID, Range: tl.Range})
+			}
+			fac.Areas = append(fac.Areas, area)
+
+			for _, site := range out.RadarSites {
+				if nmDistance(area.Center, site.Position) <= float64(area.Range) && !slices.Contains(fac.RadarSites, site.ID) {
+					fac.RadarSites = append(fac.RadarSites, site.ID)
+				}
+			}
+		}
+
+		ctx.lg.Printf("Exported %d STARS areas and %d radar sites for %s", len(fac.Areas), len(fac.RadarSites), child.ID)
+		out.Facilities = append(out.Facilities, fac)
+	}
+	return out, nil
+}
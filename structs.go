@@ -21,51 +21,13 @@ type ARTCC struct {
 			Type            string `json:"type"`
 			Name            string `json:"name"`
 			ChildFacilities []struct {
-				ID                    string        `json:"id"`
-				Type                  string        `json:"type"`
-				Name                  string        `json:"name"`
-				ChildFacilities       []interface{} `json:"childFacilities"`
-				TowerCabConfiguration struct {
-					VideoMapID                string  `json:"videoMapId"`
-					DefaultRotation           float32 `json:"defaultRotation"`
-					DefaultZoomRange          float32 `json:"defaultZoomRange"`
-					AircraftVisibilityCeiling float32 `json:"aircraftVisibilityCeiling"`
-					TowerLocation             struct {
-						Lat float64 `json:"lat"`
-						Lon float64 `json:"lon"`
-					} `json:"towerLocation"`
-				} `json:"towerCabConfiguration"`
-				AsdexConfiguration struct {
-					VideoMapID              string  `json:"videoMapId"`
-					DefaultRotation         float32 `json:"defaultRotation"`
-					DefaultZoomRange        float32 `json:"defaultZoomRange"`
-					TargetVisibilityRange   float32 `json:"targetVisibilityRange"`
-					TargetVisibilityCeiling float32 `json:"targetVisibilityCeiling"`
-					FixRules                []struct {
-						ID            string `json:"id"`
-						SearchPattern string `json:"searchPattern"`
-						FixID         string `json:"fixId"`
-					} `json:"fixRules"`
-					UseDestinationIDAsFix bool `json:"useDestinationIdAsFix"`
-					RunwayConfigurations  []struct {
-						ID                   string        `json:"id"`
-						Name                 string        `json:"name"`
-						ArrivalRunwayIds     []string      `json:"arrivalRunwayIds"`
-						DepartureRunwayIds   []string      `json:"departureRunwayIds"`
-						HoldShortRunwayPairs []interface{} `json:"holdShortRunwayPairs"`
-					} `json:"runwayConfigurations"`
-					Positions []struct {
-						ID        string        `json:"id"`
-						Name      string        `json:"name"`
-						RunwayIds []interface{} `json:"runwayIds"`
-					} `json:"positions"`
-					DefaultPositionID string `json:"defaultPositionId"`
-					TowerLocation     struct {
-						Lat float64 `json:"lat"`
-						Lon float64 `json:"lon"`
-					} `json:"towerLocation"`
-				} `json:"asdexConfiguration,omitempty"`
-				TdlsConfiguration struct {
+				ID                    string                `json:"id"`
+				Type                  string                `json:"type"`
+				Name                  string                `json:"name"`
+				ChildFacilities       []interface{}         `json:"childFacilities"`
+				TowerCabConfiguration TowerCabConfiguration `json:"towerCabConfiguration"`
+				AsdexConfiguration    AsdexConfiguration    `json:"asdexConfiguration,omitempty"`
+				TdlsConfiguration     struct {
 					MandatorySid         bool `json:"mandatorySid"`
 					MandatoryClimbout    bool `json:"mandatoryClimbout"`
 					MandatoryClimbvia    bool `json:"mandatoryClimbvia"`
@@ -151,167 +113,7 @@ type ARTCC struct {
 				NeighboringFacilityIds []string      `json:"neighboringFacilityIds"`
 				NonNasFacilityIds      []interface{} `json:"nonNasFacilityIds"`
 			} `json:"childFacilities"`
-			StarsConfiguration struct {
-				Areas []struct {
-					ID               string `json:"id"`
-					Name             string `json:"name"`
-					VisibilityCenter struct {
-						Lat float64 `json:"lat"`
-						Lon float64 `json:"lon"`
-					} `json:"visibilityCenter"`
-					SurveillanceRange       float32  `json:"surveillanceRange"`
-					UnderlyingAirports      []string `json:"underlyingAirports"`
-					SsaAirports             []string `json:"ssaAirports"`
-					TowerListConfigurations []struct {
-						ID        string  `json:"id"`
-						AirportID string  `json:"airportId"`
-						Range     float32 `json:"range"`
-					} `json:"towerListConfigurations"`
-					LdbBeaconCodesInhibited          bool `json:"ldbBeaconCodesInhibited"`
-					PdbGroundSpeedInhibited          bool `json:"pdbGroundSpeedInhibited"`
-					DisplayRequestedAltInFdb         bool `json:"displayRequestedAltInFdb"`
-					UseVfrPositionSymbol             bool `json:"useVfrPositionSymbol"`
-					ShowDestinationDepartures        bool `json:"showDestinationDepartures"`
-					ShowDestinationSatelliteArrivals bool `json:"showDestinationSatelliteArrivals"`
-					ShowDestinationPrimaryArrivals   bool `json:"showDestinationPrimaryArrivals"`
-				} `json:"areas"`
-				float32ernalAirports []string `json:"float32ernalAirports"`
-				BeaconCodeBanks      []struct {
-					ID     string  `json:"id"`
-					Type   string  `json:"type"`
-					Subset float32 `json:"subset"`
-					Start  float32 `json:"start"`
-					End    float32 `json:"end"`
-				} `json:"beaconCodeBanks"`
-				Rpcs []struct {
-					ID                    string  `json:"id"`
-					Index                 float32 `json:"index"`
-					AirportID             string  `json:"airportId"`
-					PositionSymbolTie     string  `json:"positionSymbolTie"`
-					PositionSymbolStagger string  `json:"positionSymbolStagger"`
-					MasterRunway          struct {
-						RunwayID                 string  `json:"runwayId"`
-						HeadingTolerance         float32 `json:"headingTolerance"`
-						NearSideHalfWidth        float32 `json:"nearSideHalfWidth"`
-						FarSideHalfWidth         float32 `json:"farSideHalfWidth"`
-						NearSideDistance         float64 `json:"nearSideDistance"`
-						RegionLength             float32 `json:"regionLength"`
-						TargetReferencePofloat32 struct {
-							Lat float64 `json:"lat"`
-							Lon float64 `json:"lon"`
-						} `json:"targetReferencePofloat32"`
-						TargetReferenceLineHeading       float32 `json:"targetReferenceLineHeading"`
-						TargetReferenceLineLength        float32 `json:"targetReferenceLineLength"`
-						TargetReferencePofloat32Altitude float32 `json:"targetReferencePofloat32Altitude"`
-						ImageReferencePofloat32          struct {
-							Lat float64 `json:"lat"`
-							Lon float64 `json:"lon"`
-						} `json:"imageReferencePofloat32"`
-						ImageReferenceLineHeading float64       `json:"imageReferenceLineHeading"`
-						ImageReferenceLineLength  float32       `json:"imageReferenceLineLength"`
-						TieModeOffset             float64       `json:"tieModeOffset"`
-						DescentPofloat32Distance  float64       `json:"descentPofloat32Distance"`
-						DescentPofloat32Altitude  float32       `json:"descentPofloat32Altitude"`
-						AbovePathTolerance        float32       `json:"abovePathTolerance"`
-						BelowPathTolerance        float32       `json:"belowPathTolerance"`
-						DefaultLeaderDirection    string        `json:"defaultLeaderDirection"`
-						ScratchpadPatterns        []interface{} `json:"scratchpadPatterns"`
-					} `json:"masterRunway"`
-					SlaveRunway struct {
-						RunwayID                 string  `json:"runwayId"`
-						HeadingTolerance         float32 `json:"headingTolerance"`
-						NearSideHalfWidth        float32 `json:"nearSideHalfWidth"`
-						FarSideHalfWidth         float32 `json:"farSideHalfWidth"`
-						NearSideDistance         float32 `json:"nearSideDistance"`
-						RegionLength             float32 `json:"regionLength"`
-						TargetReferencePofloat32 struct {
-							Lat float64 `json:"lat"`
-							Lon float64 `json:"lon"`
-						} `json:"targetReferencePofloat32"`
-						TargetReferenceLineHeading       float64 `json:"targetReferenceLineHeading"`
-						TargetReferenceLineLength        float32 `json:"targetReferenceLineLength"`
-						TargetReferencePofloat32Altitude float32 `json:"targetReferencePofloat32Altitude"`
-						ImageReferencePofloat32          struct {
-							Lat float64 `json:"lat"`
-							Lon float64 `json:"lon"`
-						} `json:"imageReferencePofloat32"`
-						ImageReferenceLineHeading float32       `json:"imageReferenceLineHeading"`
-						ImageReferenceLineLength  float32       `json:"imageReferenceLineLength"`
-						TieModeOffset             float64       `json:"tieModeOffset"`
-						DescentPofloat32Distance  float64       `json:"descentPofloat32Distance"`
-						DescentPofloat32Altitude  float32       `json:"descentPofloat32Altitude"`
-						AbovePathTolerance        float32       `json:"abovePathTolerance"`
-						BelowPathTolerance        float32       `json:"belowPathTolerance"`
-						DefaultLeaderDirection    string        `json:"defaultLeaderDirection"`
-						ScratchpadPatterns        []interface{} `json:"scratchpadPatterns"`
-					} `json:"slaveRunway"`
-				} `json:"rpcs"`
-				PrimaryScratchpadRules []struct {
-					ID            string   `json:"id"`
-					AirportIds    []string `json:"airportIds"`
-					SearchPattern string   `json:"searchPattern"`
-					Template      string   `json:"template"`
-					MinAltitude   float32  `json:"minAltitude,omitempty"`
-					MaxAltitude   float32  `json:"maxAltitude,omitempty"`
-				} `json:"primaryScratchpadRules"`
-				SecondaryScratchpadRules  []interface{} `json:"secondaryScratchpadRules"`
-				RnavPatterns              []interface{} `json:"rnavPatterns"`
-				Allow4CharacterScratchpad bool          `json:"allow4CharacterScratchpad"`
-				StarsHandoffIds           []struct {
-					ID            string  `json:"id"`
-					FacilityID    string  `json:"facilityId"`
-					HandoffNumber float32 `json:"handoffNumber"`
-				} `json:"starsHandoffIds"`
-				VideoMapIds []string `json:"videoMapIds"`
-				MapGroups   []struct {
-					ID     string        `json:"id"`
-					MapIds []interface{} `json:"mapIds"`
-					Tcps   []string      `json:"tcps"`
-				} `json:"mapGroups"`
-				AtpaVolumes []struct {
-					ID              string `json:"id"`
-					AirportID       string `json:"airportId"`
-					VolumeID        string `json:"volumeId"`
-					Name            string `json:"name"`
-					RunwayThreshold struct {
-						Lat float64 `json:"lat"`
-						Lon float64 `json:"lon"`
-					} `json:"runwayThreshold"`
-					Ceiling                          float32 `json:"ceiling"`
-					Floor                            float32 `json:"floor"`
-					MagneticHeading                  float32 `json:"magneticHeading"`
-					MaximumHeadingDeviation          float32 `json:"maximumHeadingDeviation"`
-					Length                           float32 `json:"length"`
-					WidthLeft                        float32 `json:"widthLeft"`
-					WidthRight                       float32 `json:"widthRight"`
-					TwoPofloat32FiveApproachDistance float32 `json:"twoPofloat32FiveApproachDistance"`
-					TwoPofloat32FiveApproachEnabled  bool    `json:"twoPofloat32FiveApproachEnabled"`
-					Scratchpads                      []struct {
-						ID               string `json:"id"`
-						Entry            string `json:"entry"`
-						ScratchPadNumber string `json:"scratchPadNumber"`
-						Type             string `json:"type"`
-					} `json:"scratchpads"`
-					Tcps []struct {
-						ID       string `json:"id"`
-						TCP      string `json:"tcp"`
-						TCPID    string `json:"tcpId"`
-						ConeType string `json:"coneType"`
-					} `json:"tcps"`
-					TCPExclusions    []interface{} `json:"tcpExclusions"`
-					ExcludedTCPIds   []interface{} `json:"excludedTcpIds"`
-					LeaderDirections []interface{} `json:"leaderDirections"`
-				} `json:"atpaVolumes"`
-				RecatEnabled           bool          `json:"recatEnabled"`
-				Lists                  []interface{} `json:"lists"`
-				ConfigurationPlans     []interface{} `json:"configurationPlans"`
-				AutomaticConsolidation bool          `json:"automaticConsolidation"`
-				Tcps                   []struct {
-					Subset   float32 `json:"subset"`
-					SectorID string  `json:"sectorId"`
-					ID       string  `json:"id"`
-				} `json:"tcps"`
-			} `json:"starsConfiguration,omitempty"`
+			StarsConfiguration        StarsConfiguration `json:"starsConfiguration,omitempty"`
 			FlightStripsConfiguration struct {
 				StripBays []struct {
 					ID            string  `json:"id"`
@@ -341,49 +143,11 @@ type ARTCC struct {
 				} `json:"starsConfiguration"`
 				TransceiverIds []string `json:"transceiverIds"`
 			} `json:"positions"`
-			NeighboringFacilityIds []string      `json:"neighboringFacilityIds"`
-			NonNasFacilityIds      []interface{} `json:"nonNasFacilityIds"`
-			TowerCabConfiguration  struct {
-				VideoMapID                string  `json:"videoMapId"`
-				DefaultRotation           float32 `json:"defaultRotation"`
-				DefaultZoomRange          float32 `json:"defaultZoomRange"`
-				AircraftVisibilityCeiling float32 `json:"aircraftVisibilityCeiling"`
-				TowerLocation             struct {
-					Lat float64 `json:"lat"`
-					Lon float64 `json:"lon"`
-				} `json:"towerLocation"`
-			} `json:"towerCabConfiguration,omitempty"`
-			AsdexConfiguration struct {
-				VideoMapID              string  `json:"videoMapId"`
-				DefaultRotation         float32 `json:"defaultRotation"`
-				DefaultZoomRange        float32 `json:"defaultZoomRange"`
-				TargetVisibilityRange   float32 `json:"targetVisibilityRange"`
-				TargetVisibilityCeiling float32 `json:"targetVisibilityCeiling"`
-				FixRules                []struct {
-					ID            string `json:"id"`
-					SearchPattern string `json:"searchPattern"`
-					FixID         string `json:"fixId"`
-				} `json:"fixRules"`
-				UseDestinationIDAsFix bool `json:"useDestinationIdAsFix"`
-				RunwayConfigurations  []struct {
-					ID                   string        `json:"id"`
-					Name                 string        `json:"name"`
-					ArrivalRunwayIds     []string      `json:"arrivalRunwayIds"`
-					DepartureRunwayIds   []string      `json:"departureRunwayIds"`
-					HoldShortRunwayPairs []interface{} `json:"holdShortRunwayPairs"`
-				} `json:"runwayConfigurations"`
-				Positions []struct {
-					ID        string        `json:"id"`
-					Name      string        `json:"name"`
-					RunwayIds []interface{} `json:"runwayIds"`
-				} `json:"positions"`
-				DefaultPositionID string `json:"defaultPositionId"`
-				TowerLocation     struct {
-					Lat float64 `json:"lat"`
-					Lon float64 `json:"lon"`
-				} `json:"towerLocation"`
-			} `json:"asdexConfiguration,omitempty"`
-			TdlsConfiguration struct {
+			NeighboringFacilityIds []string              `json:"neighboringFacilityIds"`
+			NonNasFacilityIds      []interface{}         `json:"nonNasFacilityIds"`
+			TowerCabConfiguration  TowerCabConfiguration `json:"towerCabConfiguration,omitempty"`
+			AsdexConfiguration     AsdexConfiguration    `json:"asdexConfiguration,omitempty"`
+			TdlsConfiguration      struct {
 				MandatorySid         bool `json:"mandatorySid"`
 				MandatoryClimbout    bool `json:"mandatoryClimbout"`
 				MandatoryClimbvia    bool `json:"mandatoryClimbvia"`
@@ -449,7 +213,7 @@ type ARTCC struct {
 			} `json:"geoMaps"`
 			EmergencyChecklist      []string `json:"emergencyChecklist"`
 			PositionReliefChecklist []string `json:"positionReliefChecklist"`
-			float32ernalAirports    []string `json:"float32ernalAirports"`
+			InternalAirports        []string `json:"internalAirports"`
 			BeaconCodeBanks         []struct {
 				ID       string  `json:"id"`
 				Category string  `json:"category"`
@@ -501,20 +265,9 @@ type ARTCC struct {
 		Lat float64 `json:"lat"`
 		Lon float64 `json:"lon"`
 	} `json:"visibilityCenters"`
-	AliasesLastUpdatedAt time.Time `json:"aliasesLastUpdatedAt"`
-	VideoMaps            []struct {
-		ID                      string    `json:"id"`
-		Name                    string    `json:"name"`
-		Tags                    []string  `json:"tags"`
-		ShortName               string    `json:"shortName,omitempty"`
-		SourceFileName          string    `json:"sourceFileName"`
-		LastUpdatedAt           time.Time `json:"lastUpdatedAt"`
-		StarsBrightnessCategory string    `json:"starsBrightnessCategory"`
-		StarsID                 float32   `json:"starsId,omitempty"`
-		StarsAlwaysVisible      bool      `json:"starsAlwaysVisible"`
-		TdmOnly                 bool      `json:"tdmOnly"`
-	} `json:"videoMaps"`
-	Transceivers []struct {
+	AliasesLastUpdatedAt time.Time      `json:"aliasesLastUpdatedAt"`
+	VideoMaps            []VideoMapInfo `json:"videoMaps"`
+	Transceivers         []struct {
 		ID       string `json:"id"`
 		Name     string `json:"name"`
 		Location struct {
@@ -532,43 +285,241 @@ type ARTCC struct {
 		PrecursorRules    []interface{} `json:"precursorRules"`
 		ExclusionaryRules []interface{} `json:"exclusionaryRules"`
 		Criteria          struct {
-			RouteSubstrings        []string      `json:"routeSubstrings"`
-			ExcludeRouteSubstrings []interface{} `json:"excludeRouteSubstrings"`
-			Departures             []interface{} `json:"departures"`
-			Destinations           []string      `json:"destinations"`
-			ApplicableToJets       bool          `json:"applicableToJets"`
-			ApplicableToTurboprops bool          `json:"applicableToTurboprops"`
-			ApplicableToProps      bool          `json:"applicableToProps"`
+			RouteSubstrings        []string `json:"routeSubstrings"`
+			ExcludeRouteSubstrings []string `json:"excludeRouteSubstrings"`
+			Departures             []string `json:"departures"`
+			Destinations           []string `json:"destinations"`
+			ApplicableToJets       bool     `json:"applicableToJets"`
+			ApplicableToTurboprops bool     `json:"applicableToTurboprops"`
+			ApplicableToProps      bool     `json:"applicableToProps"`
 		} `json:"criteria"`
-		DescentCrossingRestriction struct {
-			CrossingFix            string `json:"crossingFix"`
-			CrossingFixName        string `json:"crossingFixName"`
-			AltitudeConstrafloat32 struct {
-				Value              float32 `json:"value"`
-				TransitionLevel    float32 `json:"transitionLevel"`
-				Constrafloat32Type string  `json:"constrafloat32Type"`
-				IsLufl             bool    `json:"isLufl"`
-			} `json:"altitudeConstrafloat32"`
-			AltimeterStation struct {
+		DescentCrossingRestriction *struct {
+			CrossingFix        string             `json:"crossingFix"`
+			CrossingFixName    string             `json:"crossingFixName"`
+			AltitudeConstraint AltitudeConstraint `json:"altitudeConstraint"`
+			AltimeterStation   struct {
 				StationID   string `json:"stationId"`
 				StationName string `json:"stationName"`
 			} `json:"altimeterStation"`
 		} `json:"descentCrossingRestriction,omitempty"`
-		DescentRestriction struct {
+		DescentRestriction *struct {
 			CrossingLine []struct {
 				Lat float64 `json:"lat"`
 				Lon float64 `json:"lon"`
 			} `json:"crossingLine"`
-			AltitudeConstrafloat32 struct {
-				Value              float32 `json:"value"`
-				TransitionLevel    float32 `json:"transitionLevel"`
-				Constrafloat32Type string  `json:"constrafloat32Type"`
-				IsLufl             bool    `json:"isLufl"`
-			} `json:"altitudeConstrafloat32"`
+			AltitudeConstraint AltitudeConstraint `json:"altitudeConstraint"`
 		} `json:"descentRestriction,omitempty"`
 	} `json:"autoAtcRules"`
 }
 
+// RPCRunway is one runway of a STARS runway pair configuration (RPC),
+// which defines the CRDA qualification region and the reference lines
+// that approaches to it are converged onto.
+type RPCRunway struct {
+	RunwayID             string  `json:"runwayId"`
+	HeadingTolerance     float32 `json:"headingTolerance"`
+	NearSideHalfWidth    float32 `json:"nearSideHalfWidth"`
+	FarSideHalfWidth     float32 `json:"farSideHalfWidth"`
+	NearSideDistance     float32 `json:"nearSideDistance"`
+	RegionLength         float32 `json:"regionLength"`
+	TargetReferencePoint struct {
+		Lat float64 `json:"lat"`
+		Lon float64 `json:"lon"`
+	} `json:"targetReferencePoint"`
+	TargetReferenceLineHeading   float32 `json:"targetReferenceLineHeading"`
+	TargetReferenceLineLength    float32 `json:"targetReferenceLineLength"`
+	TargetReferencePointAltitude float32 `json:"targetReferencePointAltitude"`
+	ImageReferencePoint          struct {
+		Lat float64 `json:"lat"`
+		Lon float64 `json:"lon"`
+	} `json:"imageReferencePoint"`
+	ImageReferenceLineHeading float32  `json:"imageReferenceLineHeading"`
+	ImageReferenceLineLength  float32  `json:"imageReferenceLineLength"`
+	TieModeOffset             float32  `json:"tieModeOffset"`
+	DescentPointDistance      float32  `json:"descentPointDistance"`
+	DescentPointAltitude      float32  `json:"descentPointAltitude"`
+	AbovePathTolerance        float32  `json:"abovePathTolerance"`
+	BelowPathTolerance        float32  `json:"belowPathTolerance"`
+	DefaultLeaderDirection    string   `json:"defaultLeaderDirection"`
+	ScratchpadPatterns        []string `json:"scratchpadPatterns"`
+}
+
+// AltitudeConstraint is the altitude of an auto-ATC descent restriction.
+type AltitudeConstraint struct {
+	Value           float32 `json:"value"`
+	TransitionLevel float32 `json:"transitionLevel"`
+	ConstraintType  string  `json:"constraintType"`
+	IsLufl          bool    `json:"isLufl"`
+}
+
+// StarsConfiguration is the STARS configuration of a TRACON.
+type StarsConfiguration struct {
+	Areas []struct {
+		ID               string `json:"id"`
+		Name             string `json:"name"`
+		VisibilityCenter struct {
+			Lat float64 `json:"lat"`
+			Lon float64 `json:"lon"`
+		} `json:"visibilityCenter"`
+		SurveillanceRange       float32  `json:"surveillanceRange"`
+		UnderlyingAirports      []string `json:"underlyingAirports"`
+		SsaAirports             []string `json:"ssaAirports"`
+		TowerListConfigurations []struct {
+			ID        string  `json:"id"`
+			AirportID string  `json:"airportId"`
+			Range     float32 `json:"range"`
+		} `json:"towerListConfigurations"`
+		LdbBeaconCodesInhibited          bool `json:"ldbBeaconCodesInhibited"`
+		PdbGroundSpeedInhibited          bool `json:"pdbGroundSpeedInhibited"`
+		DisplayRequestedAltInFdb         bool `json:"displayRequestedAltInFdb"`
+		UseVfrPositionSymbol             bool `json:"useVfrPositionSymbol"`
+		ShowDestinationDepartures        bool `json:"showDestinationDepartures"`
+		ShowDestinationSatelliteArrivals bool `json:"showDestinationSatelliteArrivals"`
+		ShowDestinationPrimaryArrivals   bool `json:"showDestinationPrimaryArrivals"`
+	} `json:"areas"`
+	InternalAirports []string `json:"internalAirports"`
+	BeaconCodeBanks  []struct {
+		ID     string  `json:"id"`
+		Type   string  `json:"type"`
+		Subset float32 `json:"subset"`
+		Start  float32 `json:"start"`
+		End    float32 `json:"end"`
+	} `json:"beaconCodeBanks"`
+	Rpcs []struct {
+		ID                    string    `json:"id"`
+		Index                 float32   `json:"index"`
+		AirportID             string    `json:"airportId"`
+		PositionSymbolTie     string    `json:"positionSymbolTie"`
+		PositionSymbolStagger string    `json:"positionSymbolStagger"`
+		MasterRunway          RPCRunway `json:"masterRunway"`
+		SlaveRunway           RPCRunway `json:"slaveRunway"`
+	} `json:"rpcs"`
+	PrimaryScratchpadRules []struct {
+		ID            string   `json:"id"`
+		AirportIds    []string `json:"airportIds"`
+		SearchPattern string   `json:"searchPattern"`
+		Template      string   `json:"template"`
+		MinAltitude   float32  `json:"minAltitude,omitempty"`
+		MaxAltitude   float32  `json:"maxAltitude,omitempty"`
+	} `json:"primaryScratchpadRules"`
+	SecondaryScratchpadRules  []interface{} `json:"secondaryScratchpadRules"`
+	RnavPatterns              []interface{} `json:"rnavPatterns"`
+	Allow4CharacterScratchpad bool          `json:"allow4CharacterScratchpad"`
+	StarsHandoffIds           []struct {
+		ID            string  `json:"id"`
+		FacilityID    string  `json:"facilityId"`
+		HandoffNumber float32 `json:"handoffNumber"`
+	} `json:"starsHandoffIds"`
+	VideoMapIds []string `json:"videoMapIds"`
+	MapGroups   []struct {
+		ID     string        `json:"id"`
+		MapIds []interface{} `json:"mapIds"`
+		Tcps   []string      `json:"tcps"`
+	} `json:"mapGroups"`
+	AtpaVolumes []struct {
+		ID              string `json:"id"`
+		AirportID       string `json:"airportId"`
+		VolumeID        string `json:"volumeId"`
+		Name            string `json:"name"`
+		RunwayThreshold struct {
+			Lat float64 `json:"lat"`
+			Lon float64 `json:"lon"`
+		} `json:"runwayThreshold"`
+		Ceiling                      float32 `json:"ceiling"`
+		Floor                        float32 `json:"floor"`
+		MagneticHeading              float32 `json:"magneticHeading"`
+		MaximumHeadingDeviation      float32 `json:"maximumHeadingDeviation"`
+		Length                       float32 `json:"length"`
+		WidthLeft                    float32 `json:"widthLeft"`
+		WidthRight                   float32 `json:"widthRight"`
+		TwoPointFiveApproachDistance float32 `json:"twoPointFiveApproachDistance"`
+		TwoPointFiveApproachEnabled  bool    `json:"twoPointFiveApproachEnabled"`
+		Scratchpads                  []struct {
+			ID               string `json:"id"`
+			Entry            string `json:"entry"`
+			ScratchPadNumber string `json:"scratchPadNumber"`
+			Type             string `json:"type"`
+		} `json:"scratchpads"`
+		Tcps []struct {
+			ID       string `json:"id"`
+			TCP      string `json:"tcp"`
+			TCPID    string `json:"tcpId"`
+			ConeType string `json:"coneType"`
+		} `json:"tcps"`
+		TCPExclusions    []interface{} `json:"tcpExclusions"`
+		ExcludedTCPIds   []interface{} `json:"excludedTcpIds"`
+		LeaderDirections []interface{} `json:"leaderDirections"`
+	} `json:"atpaVolumes"`
+	RecatEnabled           bool          `json:"recatEnabled"`
+	Lists                  []interface{} `json:"lists"`
+	ConfigurationPlans     []interface{} `json:"configurationPlans"`
+	AutomaticConsolidation bool          `json:"automaticConsolidation"`
+	Tcps                   []struct {
+		Subset   float32 `json:"subset"`
+		SectorID string  `json:"sectorId"`
+		ID       string  `json:"id"`
+	} `json:"tcps"`
+}
+
+// TowerCabConfiguration is the tower cab display configuration of a
+// facility with a tower.
+type TowerCabConfiguration struct {
+	VideoMapID                string  `json:"videoMapId"`
+	DefaultRotation           float32 `json:"defaultRotation"`
+	DefaultZoomRange          float32 `json:"defaultZoomRange"`
+	AircraftVisibilityCeiling float32 `json:"aircraftVisibilityCeiling"`
+	TowerLocation             struct {
+		Lat float64 `json:"lat"`
+		Lon float64 `json:"lon"`
+	} `json:"towerLocation"`
+}
+
+// AsdexConfiguration is the ASDE-X display configuration of a facility.
+type AsdexConfiguration struct {
+	VideoMapID              string  `json:"videoMapId"`
+	DefaultRotation         float32 `json:"defaultRotation"`
+	DefaultZoomRange        float32 `json:"defaultZoomRange"`
+	TargetVisibilityRange   float32 `json:"targetVisibilityRange"`
+	TargetVisibilityCeiling float32 `json:"targetVisibilityCeiling"`
+	FixRules                []struct {
+		ID            string `json:"id"`
+		SearchPattern string `json:"searchPattern"`
+		FixID         string `json:"fixId"`
+	} `json:"fixRules"`
+	UseDestinationIDAsFix bool `json:"useDestinationIdAsFix"`
+	RunwayConfigurations  []struct {
+		ID                   string        `json:"id"`
+		Name                 string        `json:"name"`
+		ArrivalRunwayIds     []string      `json:"arrivalRunwayIds"`
+		DepartureRunwayIds   []string      `json:"departureRunwayIds"`
+		HoldShortRunwayPairs []interface{} `json:"holdShortRunwayPairs"`
+	} `json:"runwayConfigurations"`
+	Positions []struct {
+		ID        string        `json:"id"`
+		Name      string        `json:"name"`
+		RunwayIds []interface{} `json:"runwayIds"`
+	} `json:"positions"`
+	DefaultPositionID string `json:"defaultPositionId"`
+	TowerLocation     struct {
+		Lat float64 `json:"lat"`
+		Lon float64 `json:"lon"`
+	} `json:"towerLocation"`
+}
+
+// VideoMapInfo describes one of the ARTCC's video map files.
+type VideoMapInfo struct {
+	ID                      string    `json:"id"`
+	Name                    string    `json:"name"`
+	Tags                    []string  `json:"tags"`
+	ShortName               string    `json:"shortName,omitempty"`
+	SourceFileName          string    `json:"sourceFileName"`
+	LastUpdatedAt           time.Time `json:"lastUpdatedAt"`
+	StarsBrightnessCategory string    `json:"starsBrightnessCategory"`
+	StarsID                 float32   `json:"starsId,omitempty"`
+	StarsAlwaysVisible      bool      `json:"starsAlwaysVisible"`
+	TdmOnly                 bool      `json:"tdmOnly"`
+}
+
 type Point2LL [2]float32
 
 // StringOrInt is a helper type for fields that may be numeric or a numeric string in JSON.
@@ -623,11 +574,8 @@ type GeoJSON struct {
 }
 
 type GeoJSONFeature struct {
-	Type     string `json:"type"`
-	Geometry struct {
-		Type        string             `json:"type"`
-		Coordinates GeoJSONCoordinates `json:"coordinates"`
-	} `json:"geometry"`
+	Type       string             `json:"type"`
+	Geometry   GeoJSONGeometry    `json:"geometry"`
 	Properties *GeoJSONProperties `json:"properties"`
 }
 
@@ -646,11 +594,12 @@ type GeoJSONProperties struct {
 	Thickness int    `json:"thickness"`
 
 	// Text properties
-	Size      int  `json:"size"`
-	Underline bool `json:"underline"`
-	Opaque    bool `json:"opaque"`
-	XOffset   int  `json:"xOffset"`
-	YOffset   int  `json:"yOffset"`
+	Size      int      `json:"size"`
+	Underline bool     `json:"underline"`
+	Opaque    bool     `json:"opaque"`
+	Text      []string `json:"text"`
+	XOffset   int      `json:"xOffset"`
+	YOffset   int      `json:"yOffset"`
 }
 
 // UnmarshalJSON allows numeric fields to be provided as either numbers or numeric strings.
@@ -675,6 +624,23 @@ func (p *GeoJSONProperties) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	// Helper to decode []string fields, which may also be given as a single string
+	decodeStringSlice := func(key string, dst *[]string) {
+		b, ok := raw[key]
+		if !ok {
+			return
+		}
+		var strs []string
+		if err := json.Unmarshal(b, &strs); err == nil {
+			*dst = strs
+			return
+		}
+		var single string
+		if err := json.Unmarshal(b, &single); err == nil && single != "" {
+			*dst = []string{single}
+		}
+	}
+
 	// Helper to decode an int that may be a JSON number or a quoted numeric string
 	decodeInt := func(key string, dst *int) {
 		b, ok := raw[key]
@@ -753,25 +719,84 @@ func (p *GeoJSONProperties) UnmarshalJSON(data []byte) error {
 	decodeInt("size", &p.Size)
 	decodeBool("underline", &p.Underline)
 	decodeBool("opaque", &p.Opaque)
+	decodeStringSlice("text", &p.Text)
 	decodeInt("xOffset", &p.XOffset)
 	decodeInt("yOffset", &p.YOffset)
 
 	return nil
 }
 
-// We only extract lines (at the moment at least) and so we only worry
-// about [][2]float32s for coordinates. (For pofloat32s, this would be
-// a single [2]float32 and for polygons, it would be [][][2]float32...)
-type GeoJSONCoordinates []Point2LL
+// GeoJSONGeometry decodes any GeoJSON geometry type and flattens it into
+// the points and polylines that we extract. Polygon rings (including
+// those of each part of a MultiPolygon) are returned as closed lines and
+// MultiLineString parts as separate lines; GeometryCollection members are
+// flattened recursively.
+type GeoJSONGeometry struct {
+	Type   string
+	Points []Point2LL
+	Lines  [][]Point2LL
+}
 
-func (c *GeoJSONCoordinates) UnmarshalJSON(d []byte) error {
-	*c = nil
+func (g *GeoJSONGeometry) UnmarshalJSON(d []byte) error {
+	*g = GeoJSONGeometry{}
 
-	var coords []Point2LL
-	if err := json.Unmarshal(d, &coords); err == nil {
-		*c = coords
+	var raw struct {
+		Type        string            `json:"type"`
+		Coordinates json.RawMessage   `json:"coordinates"`
+		Geometries  []GeoJSONGeometry `json:"geometries"`
+	}
+	if err := json.Unmarshal(d, &raw); err != nil {
+		// Don't report any errors; the feature will just be skipped.
+		return nil
+	}
+	g.Type = raw.Type
+
+	closeRing := func(ring []Point2LL) []Point2LL {
+		if len(ring) > 1 && ring[0] != ring[len(ring)-1] {
+			ring = append(ring, ring[0])
+		}
+		return ring
+	}
+
+	// As above, malformed coordinates leave the geometry empty rather
+	// than failing the whole file.
+	switch raw.Type {
+	case "Point":
+		var pt Point2LL
+		if err := json.Unmarshal(raw.Coordinates, &pt); err == nil {
+			g.Points = []Point2LL{pt}
+		}
+	case "MultiPoint":
+		_ = json.Unmarshal(raw.Coordinates, &g.Points)
+	case "LineString":
+		var line []Point2LL
+		if err := json.Unmarshal(raw.Coordinates, &line); err == nil && len(line) > 0 {
+			g.Lines = [][]Point2LL{line}
+		}
+	case "MultiLineString":
+		_ = json.Unmarshal(raw.Coordinates, &g.Lines)
+	case "Polygon":
+		var rings [][]Point2LL
+		if err := json.Unmarshal(raw.Coordinates, &rings); err == nil {
+			for _, ring := range rings {
+				g.Lines = append(g.Lines, closeRing(ring))
+			}
+		}
+	case "MultiPolygon":
+		var polys [][][]Point2LL
+		if err := json.Unmarshal(raw.Coordinates, &polys); err == nil {
+			for _, rings := range polys {
+				for _, ring := range rings {
+					g.Lines = append(g.Lines, closeRing(ring))
+				}
+			}
+		}
+	case "GeometryCollection":
+		for _, sub := range raw.Geometries {
+			g.Points = append(g.Points, sub.Points...)
+			g.Lines = append(g.Lines, sub.Lines...)
+		}
 	}
-	// Don't report any errors but assume that it's a pofloat32, polygon, ...
 	return nil
 }
 
@@ -779,17 +804,75 @@ func (c *GeoJSONCoordinates) UnmarshalJSON(d []byte) error {
 // Output structs
 
 type ERAMMap struct {
+	// BcgName is the BCG of the filter; lines, text and symbols also
+	// carry their own since a filter may mix brightness groups.
 	BcgName    string
 	LabelLine1 string
 	LabelLine2 string
 	Name       string
-	Lines      [][]Point2LL
+	Filter     int // 1-based CRC filter index
+	// Placeholder is set for the empty maps emitted for blank or empty
+	// filters with -keep-blank-filters.
+	Placeholder bool
+	// VideoMapIds lists the video maps that contributed to the map.
+	VideoMapIds []string
+	// StyledLines holds the map's lines. With -bake-dashes, dashed lines
+	// are split into solid dash segments that keep the line's BCG.
+	StyledLines []ERAMLine
+	Text        []ERAMText
+	Symbols     []ERAMSymbol
+}
+
+// ERAMLine is a single polyline along with its resolved style and thickness.
+type ERAMLine struct {
+	Points    []Point2LL
+	Style     LineStyle
+	Thickness int
+	BcgName   string
+}
+
+// ERAMText is a single text label from a video map; multi-line labels
+// are joined with newlines.
+type ERAMText struct {
+	Location  Point2LL
+	Text      string
+	Size      int
+	Underline bool
+	Opaque    bool
+	XOffset   int
+	YOffset   int
+	BcgName   string
+}
+
+// ERAMSymbol is a single map symbol.
+type ERAMSymbol struct {
+	Style    SymbolStyle
+	Size     int
+	Location Point2LL
+	BcgName  string
 }
 
 type ERAMMapGroup struct {
+	Name       string
 	Maps       []ERAMMap
 	LabelLine1 string
 	LabelLine2 string
+	// BcgMenu holds the labels of the geomap's BCG groups in menu order;
+	// unlabeled groups are empty strings.
+	BcgMenu []string
+	// FilterMenu holds every filter menu slot in order, including blank
+	// ones, so that the menu layout can be reproduced.
+	FilterMenu []ERAMFilterMenuItem
+}
+
+type ERAMFilterMenuItem struct {
+	ID         string
+	Filter     int // 1-based CRC filter index
+	LabelLine1 string
+	LabelLine2 string
 }
 
-type ERAMMapGroups map[string]ERAMMapGroup
+// ERAMMapGroups holds the geomap groups in the order of the ERAM
+// configuration. A slice rather than a map keeps the encoded output
+// byte-for-byte reproducible between runs.
+type ERAMMapGroups []ERAMMapGroup
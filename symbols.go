@@ -0,0 +1,66 @@
+package main
+
+// SymbolStyle identifies one of the ERAM map symbols that CRC can draw at
+// a Point feature.
+type SymbolStyle int
+
+const (
+	SymbolStyleUnknown SymbolStyle = iota
+	SymbolStyleObstruction1
+	SymbolStyleObstruction2
+	SymbolStyleHeliport
+	SymbolStyleNuclear
+	SymbolStyleEmergencyAirport
+	SymbolStyleRadar
+	SymbolStyleIaf
+	SymbolStyleRnavOnlyWaypoint
+	SymbolStyleRnav
+	SymbolStyleAirwayIntersections
+	SymbolStyleNdb
+	SymbolStyleVor
+	SymbolStyleOtherWaypoints
+	SymbolStyleAirport
+	SymbolStyleSatelliteAirport
+	SymbolStyleTacan
+	SymbolStyleDme
+)
+
+var symbolStyleNames = map[SymbolStyle]string{
+	SymbolStyleUnknown:             "Unknown",
+	SymbolStyleObstruction1:        "Obstruction1",
+	SymbolStyleObstruction2:        "Obstruction2",
+	SymbolStyleHeliport:            "Heliport",
+	SymbolStyleNuclear:             "Nuclear",
+	SymbolStyleEmergencyAirport:    "EmergencyAirport",
+	SymbolStyleRadar:               "Radar",
+	SymbolStyleIaf:                 "Iaf",
+	SymbolStyleRnavOnlyWaypoint:    "RnavOnlyWaypoint",
+	SymbolStyleRnav:                "Rnav",
+	SymbolStyleAirwayIntersections: "AirwayIntersections",
+	SymbolStyleNdb:                 "Ndb",
+	SymbolStyleVor:                 "Vor",
+	SymbolStyleOtherWaypoints:      "OtherWaypoints",
+	SymbolStyleAirport:             "Airport",
+	SymbolStyleSatelliteAirport:    "SatelliteAirport",
+	SymbolStyleTacan:               "Tacan",
+	SymbolStyleDme:                 "Dme",
+}
+
+func (s SymbolStyle) String() string {
+	if n, ok := symbolStyleNames[s]; ok {
+		return n
+	}
+	return "Unknown"
+}
+
+// parseSymbolStyle maps a CRC symbol style string to a SymbolStyle,
+// returning SymbolStyleUnknown if it isn't recognized.
+func parseSymbolStyle(s string) SymbolStyle {
+	s = normalizeStyle(s)
+	for style, name := range symbolStyleNames {
+		if style != SymbolStyleUnknown && normalizeStyle(name) == s {
+			return style
+		}
+	}
+	return SymbolStyleUnknown
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"slices"
+	"strings"
+)
+
+// TowerExport holds the tower cab and ASDE-X display configurations of
+// an ARTCC's facilities, along with the geometry of the video maps they
+// use, as a vice tower/ground display configuration.
+type TowerExport struct {
+	Facilities []TowerFacility `json:"facilities"`
+}
+
+type TowerFacility struct {
+	ID       string           `json:"id"`
+	Name     string           `json:"name"`
+	Parent   string           `json:"parent,omitempty"` // TRACON the tower is under
+	TowerCab *TowerCabDisplay `json:"tower_cab,omitempty"`
+	ASDEX    *ASDEXDisplay    `json:"asdex,omitempty"`
+}
+
+type TowerCabDisplay struct {
+	Rotation                  float32        `json:"rotation"`   // degrees
+	ZoomRange                 float32        `json:"zoom_range"` // nm
+	AircraftVisibilityCeiling float32        `json:"aircraft_visibility_ceiling"`
+	TowerLocation             Point2LL       `json:"tower_location"` // [lon, lat]
+	VideoMap                  *TowerVideoMap `json:"video_map,omitempty"`
+}
+
+type ASDEXDisplay struct {
+	Rotation                float32                    `json:"rotation"`   // degrees
+	ZoomRange               float32                    `json:"zoom_range"` // nm
+	TargetVisibilityRange   float32                    `json:"target_visibility_range"`
+	TargetVisibilityCeiling float32                    `json:"target_visibility_ceiling"`
+	TowerLocation           Point2LL                   `json:"tower_location"` // [lon, lat]
+	FixRules                []ASDEXFixRule             `json:"fix_rules,omitempty"`
+	UseDestinationAsFix     bool                       `json:"use_destination_as_fix,omitempty"`
+	RunwayConfigurations    []ASDEXRunwayConfiguration `json:"runway_configurations,omitempty"`
+	Positions               []ASDEXPosition            `json:"positions,omitempty"`
+	DefaultPosition         string                     `json:"default_position,omitempty"`
+	VideoMap                *TowerVideoMap             `json:"video_map,omitempty"`
+}
+
+type ASDEXFixRule struct {
+	Pattern string `json:"pattern"`
+	Fix     string `json:"fix"`
+}
+
+type ASDEXRunwayConfiguration struct {
+	ID               string   `json:"id"`
+	Name             string   `json:"name"`
+	ArrivalRunways   []string `json:"arrival_runways,omitempty"`
+	DepartureRunways []string `json:"departure_runways,omitempty"`
+}
+
+type ASDEXPosition struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Runways []string `json:"runways,omitempty"`
+}
+
+// TowerVideoMap is the geometry of the video map drawn on a tower cab or
+// ASDE-X display. Tower displays have no brightness categories, so unlike
+// the ERAM maps' elements these carry no BCG.
+type TowerVideoMap struct {
+	ID      string        `json:"id"`
+	Name    string        `json:"name,omitempty"`
+	Lines   []TowerLine   `json:"lines,omitempty"`
+	Text    []TowerText   `json:"text,omitempty"`
+	Symbols []TowerSymbol `json:"symbols,omitempty"`
+}
+
+type TowerLine struct {
+	Points    []Point2LL `json:"points"` // [lon, lat]
+	Style     string     `json:"style"`  // LineStyle name, e.g. "ShortDashed"
+	Thickness int        `json:"thickness,omitempty"`
+}
+
+// TowerText is a text label; multi-line labels are joined with newlines.
+type TowerText struct {
+	Location  Point2LL `json:"location"` // [lon, lat]
+	Text      string   `json:"text"`
+	Size      int      `json:"size,omitempty"`
+	Underline bool     `json:"underline,omitempty"`
+	Opaque    bool     `json:"opaque,omitempty"`
+	XOffset   int      `json:"x_offset,omitempty"`
+	YOffset   int      `json:"y_offset,omitempty"`
+}
+
+type TowerSymbol struct {
+	Style    string   `json:"style"` // SymbolStyle name, e.g. "Vor"
+	Size     int      `json:"size,omitempty"`
+	Location Point2LL `json:"location"` // [lon, lat]
+}
+
+func exportTowers(ctx *exportContext) (any, error) {
+	out := TowerExport{Facilities: []TowerFacility{}}
+
+	add := func(id, name, parent string, tc *TowerCabConfiguration, ax *AsdexConfiguration) {
+		fac := TowerFacility{ID: id, Name: name, Parent: parent}
+		if tc.VideoMapID != "" {
+			fac.TowerCab = &TowerCabDisplay{
+				Rotation:                  tc.DefaultRotation,
+				ZoomRange:                 tc.DefaultZoomRange,
+				AircraftVisibilityCeiling: tc.AircraftVisibilityCeiling,
+				TowerLocation:             Point2LL{float32(tc.TowerLocation.Lon), float32(tc.TowerLocation.Lat)},
+				VideoMap:                  towerVideoMap(ctx, id, tc.VideoMapID),
+			}
+		}
+		if ax.VideoMapID != "" {
+			fac.ASDEX = convertASDEX(ctx, id, ax)
+		}
+		if fac.TowerCab == nil && fac.ASDEX == nil {
+			return
+		}
+		ctx.lg.Printf("  %s: tower cab %t, ASDE-X %t", id, fac.TowerCab != nil, fac.ASDEX != nil)
+		out.Facilities = append(out.Facilities, fac)
+	}
+
+	for _, child := range ctx.artcc.Facility.ChildFacilities {
+		add(child.ID, child.Name, "", &child.TowerCabConfiguration, &child.AsdexConfiguration)
+		for _, tower := range child.ChildFacilities {
+			add(tower.ID, tower.Name, child.ID, &tower.TowerCabConfiguration, &tower.AsdexConfiguration)
+		}
+	}
+	return out, nil
+}
+
+func convertASDEX(ctx *exportContext, facilityID string, ax *AsdexConfiguration) *ASDEXDisplay {
+	d := &ASDEXDisplay{
+		Rotation:                ax.DefaultRotation,
+		ZoomRange:               ax.DefaultZoomRange,
+		TargetVisibilityRange:   ax.TargetVisibilityRange,
+		TargetVisibilityCeiling: ax.TargetVisibilityCeiling,
+		TowerLocation:           Point2LL{float32(ax.TowerLocation.Lon), float32(ax.TowerLocation.Lat)},
+		UseDestinationAsFix:     ax.UseDestinationIDAsFix,
+		VideoMap:                towerVideoMap(ctx, facilityID, ax.VideoMapID),
+	}
+	for _, r := range ax.FixRules {
+		d.FixRules = append(d.FixRules, ASDEXFixRule{Pattern: r.SearchPattern, Fix: r.FixID})
+	}
+	for _, rc := range ax.RunwayConfigurations {
+		d.RunwayConfigurations = append(d.RunwayConfigurations, ASDEXRunwayConfiguration{
+			ID:               rc.ID,
+			Name:             rc.Name,
+			ArrivalRunways:   rc.ArrivalRunwayIds,
+			DepartureRunways: rc.DepartureRunwayIds,
+		})
+	}
+	for _, p := range ax.Positions {
+		pos := ASDEXPosition{ID: p.ID, Name: p.Name}
+		for _, rwy := range p.RunwayIds {
+			if s, ok := rwy.(string); ok {
+				pos.Runways = append(pos.Runways, s)
+			}
+		}
+		if p.ID == ax.DefaultPositionID {
+			d.DefaultPosition = p.Name
+		}
+		d.Positions = append(d.Positions, pos)
+	}
+	return d
+}
+
+// towerVideoMap returns the geometry of the given video map, or nil with
+// a warning if it can't be loaded; the display configuration is still
+// useful without it.
+func towerVideoMap(ctx *exportContext, facilityID, videoMapID string) *TowerVideoMap {
+	vm, err := ctx.cache.Get(videoMapID)
+	if err != nil {
+		ctx.lg.Printf("  Warning: %s: skipping video map %s: %v", facilityID, videoMapID, err)
+		return nil
+	}
+
+	tm := &TowerVideoMap{ID: videoMapID}
+	if i := slices.IndexFunc(ctx.artcc.VideoMaps, func(info VideoMapInfo) bool { return info.ID == videoMapID }); i != -1 {
+		tm.Name = ctx.artcc.VideoMaps[i].Name
+	}
+
+	for _, f := range vm.All {
+		p := &f.Props
+		switch f.Kind {
+		case featureLine:
+			for _, line := range f.Geometry.Lines {
+				tm.Lines = append(tm.Lines, TowerLine{Points: line, Style: f.LineStyle.String(), Thickness: p.Thickness})
+			}
+		case featureText:
+			for _, pt := range f.Geometry.Points {
+				tm.Text = append(tm.Text, TowerText{
+					Location:  pt,
+					Text:      strings.Join(p.Text, "\n"),
+					Size:      p.Size,
+					Underline: p.Underline,
+					Opaque:    p.Opaque,
+					XOffset:   p.XOffset,
+					YOffset:   p.YOffset,
+				})
+			}
+		case featureSymbol:
+			for _, pt := range f.Geometry.Points {
+				tm.Symbols = append(tm.Symbols, TowerSymbol{Style: f.SymbolStyle.String(), Size: p.Size, Location: pt})
+			}
+		}
+	}
+	return tm
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// featureKind is what a video map feature is extracted as.
+type featureKind int
+
+const (
+	featureLine featureKind = iota
+	featureText
+	featureSymbol
+)
+
+// resolvedFeature is a video map feature with the file's line, text or
+// symbol defaults applied to its properties.
+type resolvedFeature struct {
+	Kind        featureKind
+	Props       GeoJSONProperties
+	Geometry    *GeoJSONGeometry
+	LineStyle   LineStyle
+	SymbolStyle SymbolStyle
+}
+
+// videoMap is a parsed video map file with its features bucketed by their
+// (1-based) CRC filter numbers. All holds every feature, including those
+// without filters, for consumers such as STARS that don't use them.
+type videoMap struct {
+	ID       string
+	All      []resolvedFeature
+	ByFilter map[int][]resolvedFeature
+	// Styles that weren't recognized; such lines are drawn solid and
+	// such symbols are dropped.
+	UnknownLineStyles   []string
+	UnknownSymbolStyles []string
+}
+
+// videoMapCache loads each video map file of an ARTCC at most once, no
+// matter how many geomaps and filters refer to it. Load errors are cached
+// as well.
+type videoMapCache struct {
+	dir  string
+	maps map[string]*videoMap
+	errs map[string]error
+}
+
+func newVideoMapCache(dir string) *videoMapCache {
+	return &videoMapCache{
+		dir:  dir,
+		maps: make(map[string]*videoMap),
+		errs: make(map[string]error),
+	}
+}
+
+// Get returns the video map with the given ID, loading it if necessary.
+func (c *videoMapCache) Get(id string) (*videoMap, error) {
+	if vm, ok := c.maps[id]; ok {
+		return vm, nil
+	}
+	if err, ok := c.errs[id]; ok {
+		return nil, err
+	}
+
+	vm, err := loadVideoMap(filepath.Join(c.dir, id+".geojson"), id)
+	if err != nil {
+		c.errs[id] = err
+		return nil, err
+	}
+	c.maps[id] = vm
+	return vm, nil
+}
+
+// Loaded returns all of the video maps that were loaded successfully,
+// sorted by ID.
+func (c *videoMapCache) Loaded() []*videoMap {
+	var vms []*videoMap
+	for _, id := range slices.Sorted(maps.Keys(c.maps)) {
+		vms = append(vms, c.maps[id])
+	}
+	return vms
+}
+
+func loadVideoMap(fn, id string) (*videoMap, error) {
+	file, err := os.Open(fn)
+	if err != nil {
+		return nil, fmt.Errorf("opening video map file: %w", err)
+	}
+	defer file.Close()
+
+	var gj GeoJSON
+	if err := json.NewDecoder(file).Decode(&gj); err != nil {
+		return nil, fmt.Errorf("decoding video map file: %w", err)
+	}
+	return resolveVideoMap(id, &gj), nil
+}
+
+// resolveVideoMap applies the per-file defaults to each feature of gj and
+// buckets the features by filter in a single pass.
+func resolveVideoMap(id string, gj *GeoJSON) *videoMap {
+	vm := &videoMap{ID: id, ByFilter: make(map[int][]resolvedFeature)}
+
+	// Collect per-file defaults from special features
+	lineDefaults := GeoJSONProperties{}
+	textDefaults := GeoJSONProperties{}
+	symbolDefaults := GeoJSONProperties{}
+	for _, f := range gj.Features {
+		if f.Properties == nil {
+			continue
+		}
+		if f.Properties.IsLineDefaults {
+			lineDefaults = *f.Properties
+		}
+		if f.Properties.IsTextDefaults {
+			textDefaults = *f.Properties
+		}
+		if f.Properties.IsSymbolDefaults {
+			symbolDefaults = *f.Properties
+		}
+	}
+
+	unknownLineStyles := make(map[string]bool)
+	unknownSymbolStyles := make(map[string]bool)
+
+	// Process features with fallback to defaults
+	for i := range gj.Features {
+		feature := &gj.Features[i]
+		if feature.Type != "Feature" {
+			continue
+		}
+		// Skip defaults features themselves
+		if feature.Properties != nil && (feature.Properties.IsLineDefaults || feature.Properties.IsTextDefaults || feature.Properties.IsSymbolDefaults) {
+			continue
+		}
+
+		// Determine effective properties by applying defaults
+		rf := resolvedFeature{Geometry: &feature.Geometry}
+		eff := &rf.Props
+		if feature.Properties != nil {
+			*eff = *feature.Properties
+		}
+
+		// Features with any line geometry are treated as lines; the
+		// points of mixed GeometryCollections are ignored.
+		switch {
+		case len(feature.Geometry.Lines) > 0:
+			rf.Kind = featureLine
+			// Apply defaults where missing
+			if eff.Bcg == 0 && lineDefaults.Bcg != 0 {
+				eff.Bcg = lineDefaults.Bcg
+			}
+			if len(eff.Filters) == 0 && len(lineDefaults.Filters) != 0 {
+				eff.Filters = append([]int(nil), lineDefaults.Filters...)
+			}
+			if eff.Style == "" && lineDefaults.Style != "" {
+				eff.Style = lineDefaults.Style
+			}
+			if eff.Thickness == 0 && lineDefaults.Thickness != 0 {
+				eff.Thickness = lineDefaults.Thickness
+			}
+
+			var ok bool
+			if rf.LineStyle, ok = parseLineStyle(eff.Style); !ok {
+				unknownLineStyles[eff.Style] = true
+			}
+
+		case len(feature.Geometry.Points) > 0 && len(eff.Text) > 0:
+			rf.Kind = featureText
+			// Apply defaults where missing
+			if eff.Bcg == 0 && textDefaults.Bcg != 0 {
+				eff.Bcg = textDefaults.Bcg
+			}
+			if len(eff.Filters) == 0 && len(textDefaults.Filters) != 0 {
+				eff.Filters = append([]int(nil), textDefaults.Filters...)
+			}
+			if eff.Size == 0 && textDefaults.Size != 0 {
+				eff.Size = textDefaults.Size
+			}
+			if !eff.Underline && textDefaults.Underline {
+				eff.Underline = textDefaults.Underline
+			}
+			if !eff.Opaque && textDefaults.Opaque {
+				eff.Opaque = textDefaults.Opaque
+			}
+			if eff.XOffset == 0 && textDefaults.XOffset != 0 {
+				eff.XOffset = textDefaults.XOffset
+			}
+			if eff.YOffset == 0 && textDefaults.YOffset != 0 {
+				eff.YOffset = textDefaults.YOffset
+			}
+
+		case len(feature.Geometry.Points) > 0:
+			// Points without text are symbols
+			rf.Kind = featureSymbol
+			if eff.Bcg == 0 && symbolDefaults.Bcg != 0 {
+				eff.Bcg = symbolDefaults.Bcg
+			}
+			if len(eff.Filters) == 0 && len(symbolDefaults.Filters) != 0 {
+				eff.Filters = append([]int(nil), symbolDefaults.Filters...)
+			}
+			if eff.Style == "" && symbolDefaults.Style != "" {
+				eff.Style = symbolDefaults.Style
+			}
+			if eff.Size == 0 && symbolDefaults.Size != 0 {
+				eff.Size = symbolDefaults.Size
+			}
+
+			if rf.SymbolStyle = parseSymbolStyle(eff.Style); rf.SymbolStyle == SymbolStyleUnknown {
+				unknownSymbolStyles[eff.Style] = true
+				continue
+			}
+
+		default:
+			continue
+		}
+
+		vm.All = append(vm.All, rf)
+		// CRC filters are 1-based; a feature may be in several filters
+		for _, filter := range eff.Filters {
+			vm.ByFilter[filter] = append(vm.ByFilter[filter], rf)
+		}
+	}
+
+	vm.UnknownLineStyles = slices.Sorted(maps.Keys(unknownLineStyles))
+	vm.UnknownSymbolStyles = slices.Sorted(maps.Keys(unknownSymbolStyles))
+	return vm
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Size of the synthetic ARTCC used by the benchmarks.
+const (
+	benchVideoMaps      = 10
+	benchFeaturesPerMap = 1000
+	benchFilters        = 20
+	benchPointsPerLine  = 10
+	benchARTCC          = "ZBM"
+)
+
+// writeBenchARTCC writes a synthetic CRC directory with a single geomap
+// whose filters all draw from every one of its video maps, and returns
+// the directory along with the video map IDs.
+func writeBenchARTCC(b *testing.B) (string, []string) {
+	b.Helper()
+	dir := b.TempDir()
+	vmDir := filepath.Join(dir, "VideoMaps", benchARTCC)
+	if err := os.MkdirAll(vmDir, 0o755); err != nil {
+		b.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "ARTCCs"), 0o755); err != nil {
+		b.Fatal(err)
+	}
+
+	var ids []string
+	for i := range benchVideoMaps {
+		id := fmt.Sprintf("vm%d", i)
+		ids = append(ids, id)
+
+		var features []map[string]any
+		for j := range benchFeaturesPerMap {
+			var coords [][2]float64
+			for k := range benchPointsPerLine {
+				coords = append(coords, [2]float64{-75 + float64(j)*0.001, 40 + float64(k)*0.01})
+			}
+			features = append(features, map[string]any{
+				"type":     "Feature",
+				"geometry": map[string]any{"type": "LineString", "coordinates": coords},
+				"properties": map[string]any{
+					"bcg":     1 + j%5,
+					"filters": []int{1 + j%benchFilters, 1 + (j+7)%benchFilters},
+					"style":   "Solid",
+				},
+			})
+		}
+		writeJSONFile(b, filepath.Join(vmDir, id+".geojson"),
+			map[string]any{"type": "FeatureCollection", "features": features})
+	}
+
+	var filterMenu []map[string]any
+	var bcgMenu []string
+	for i := range benchFilters {
+		filterMenu = append(filterMenu, map[string]any{"id": fmt.Sprint(i), "labelLine1": fmt.Sprintf("F%d", i+1), "labelLine2": ""})
+		bcgMenu = append(bcgMenu, fmt.Sprint(1+i%5))
+	}
+	writeJSONFile(b, filepath.Join(dir, "ARTCCs", benchARTCC+".json"), map[string]any{
+		"id": benchARTCC,
+		"facility": map[string]any{
+			"id": benchARTCC,
+			"eramConfiguration": map[string]any{
+				"geoMaps": []map[string]any{{
+					"id": "g1", "name": "BENCH", "labelLine1": "BENCH", "labelLine2": "",
+					"filterMenu": filterMenu, "bcgMenu": bcgMenu, "videoMapIds": ids,
+				}},
+			},
+		},
+	})
+	return dir, ids
+}
+
+func writeJSONFile(b *testing.B, fn string, v any) {
+	b.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		b.Fatal(err)
+	}
+	if err := os.WriteFile(fn, data, 0o644); err != nil {
+		b.Fatal(err)
+	}
+}
+
+// BenchmarkFilterLoop compares parsing each video map once and bucketing
+// its features by filter against decoding every video map again for each
+// filter, as was done before the video map cache.
+func BenchmarkFilterLoop(b *testing.B) {
+	dir, ids := writeBenchARTCC(b)
+	vmDir := filepath.Join(dir, "VideoMaps", benchARTCC)
+
+	b.Run("cached", func(b *testing.B) {
+		for range b.N {
+			cache := newVideoMapCache(vmDir)
+			n := 0
+			for filter := 1; filter <= benchFilters; filter++ {
+				for _, id := range ids {
+					vm, err := cache.Get(id)
+					if err != nil {
+						b.Fatal(err)
+					}
+					n += len(vm.ByFilter[filter])
+				}
+			}
+			if n == 0 {
+				b.Fatal("no features matched")
+			}
+		}
+	})
+
+	b.Run("per-filter", func(b *testing.B) {
+		for range b.N {
+			n := 0
+			for filter := 1; filter <= benchFilters; filter++ {
+				for _, id := range ids {
+					vm, err := loadVideoMap(filepath.Join(vmDir, id+".geojson"), id)
+					if err != nil {
+						b.Fatal(err)
+					}
+					n += len(vm.ByFilter[filter])
+				}
+			}
+			if n == 0 {
+				b.Fatal("no features matched")
+			}
+		}
+	})
+}
+
+// BenchmarkProcessARTCC runs the whole conversion of the synthetic ARTCC.
+func BenchmarkProcessARTCC(b *testing.B) {
+	dir, _ := writeBenchARTCC(b)
+	opts := options{outDir: b.TempDir(), dashPatterns: defaultDashPatterns(), exports: make(exportSet)}
+	lg := log.New(io.Discard, "", 0)
+
+	b.ResetTimer()
+	for range b.N {
+		if _, err := processARTCC(dir, benchARTCC, opts, lg); err != nil {
+			b.Fatal(err)
+		}
+	}
+}